@@ -4,27 +4,39 @@ import (
 	"github.com/kkishi/mtg/model"
 )
 
-// ManaAbility implements model.ActivatedAbility.
-var _ model.ActivatedAbility = (*ManaAbility)(nil)
+// ManaAbility implements model.ManaAbility.
+var _ model.ManaAbility = (*ManaAbility)(nil)
 
+// ManaAbility taps its permanent to add Mana. The permanent then deals Damage
+// to its controller, as painlands do.
 type ManaAbility struct {
-	Mana model.Mana
+	Mana   model.Mana
+	Damage int
+}
+
+func (ma *ManaAbility) IsManaAbility() {}
+
+func (ma *ManaAbility) Cost() model.Cost {
+	return tap
 }
 
 func (ma *ManaAbility) Commands(c *model.Context) []model.Command {
-	return []model.Command{
-		&MultiCommand{
-			Commands: []model.Command{
-				&TapCommand{
-					Permanent: c.Permanent,
-				},
-				&AddManaCommand{
-					Manas:  []model.Mana{ma.Mana},
-					Player: c.Player,
-				},
-			},
+	cmds := []model.Command{
+		&AddManaCommand{
+			Manas:  []model.Mana{ma.Mana},
+			Player: c.Player,
 		},
 	}
+	if ma.Damage > 0 {
+		cmds = append(cmds, c.Game.Damage(model.Event{
+			Kind:      model.DealsDamage,
+			Player:    c.Player,
+			Permanent: c.Permanent,
+			Target:    c.Player,
+			Amount:    ma.Damage,
+		})...)
+	}
+	return cmds
 }
 
 // MultiCommand implements model.Command.
@@ -77,3 +89,35 @@ func (amc *AddManaCommand) Undo() {
 	amc.Player.ManaPool =
 		amc.Player.ManaPool[0 : len(amc.Player.ManaPool)-len(amc.Manas)]
 }
+
+// UntapCommand implements model.Command.
+var _ model.Command = (*UntapCommand)(nil)
+
+type UntapCommand struct {
+	Permanent *model.Permanent
+}
+
+func (uc *UntapCommand) Execute() {
+	uc.Permanent.Tapped = false
+}
+
+func (uc *UntapCommand) Undo() {
+	uc.Permanent.Tapped = true
+}
+
+// LifeCommand implements model.Command.
+var _ model.Command = (*LifeCommand)(nil)
+
+// LifeCommand gains Player N life, or loses them -N when N is negative.
+type LifeCommand struct {
+	Player *model.Player
+	N      int
+}
+
+func (lc *LifeCommand) Execute() {
+	lc.Player.Life += lc.N
+}
+
+func (lc *LifeCommand) Undo() {
+	lc.Player.Life -= lc.N
+}
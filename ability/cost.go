@@ -0,0 +1,228 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// Activated implements model.ActivatedAbility and model.Targeted.
+var (
+	_ model.ActivatedAbility = (*Activated)(nil)
+	_ model.Targeted         = (*Activated)(nil)
+)
+
+// Activated is an activated ability: "Costs: Effect". With SorcerySpeed it
+// may only be activated as a sorcery.
+type Activated struct {
+	Costs        model.Costs
+	Effect       model.Effect
+	SorcerySpeed bool
+}
+
+// Activated implements model.Restricted.
+var _ model.Restricted = (*Activated)(nil)
+
+func (a *Activated) CanActivate(c *model.Context) bool {
+	return !a.SorcerySpeed || c.Game.SorcerySpeed(c.Player)
+}
+
+func (a *Activated) Cost() model.Cost {
+	return a.Costs
+}
+
+// Targets returns the targets of Effect.
+func (a *Activated) Targets() []model.TargetSpec {
+	if t, ok := a.Effect.(model.Targeted); ok {
+		return t.Targets()
+	}
+	return nil
+}
+
+func (a *Activated) Commands(c *model.Context) []model.Command {
+	return a.Effect.Commands(c)
+}
+
+// Tap implements model.Cost.
+var _ model.Cost = (*Tap)(nil)
+
+// Tap is the tap symbol: the permanent taps, which a creature can only do once
+// it has been under its controller's control since their turn began, unless
+// it has haste.
+type Tap struct{}
+
+// tap is shared by the abilities costing {T}.
+var tap = &Tap{}
+
+func (t *Tap) CanPay(c *model.Context) bool {
+	perm := c.Permanent
+	if perm.Tapped {
+		return false
+	}
+	if !perm.SummoningSick {
+		return true
+	}
+	ch := c.Game.Characteristics(perm)
+	return !ch.Is(model.Creature) || ch.HasKeyword(model.Haste)
+}
+
+func (t *Tap) Pay(c *model.Context) []model.Command {
+	return []model.Command{&TapCommand{Permanent: c.Permanent}}
+}
+
+// Untap implements model.Cost.
+var _ model.Cost = (*Untap)(nil)
+
+// Untap is the untap symbol, which summoning sickness restricts like Tap.
+type Untap struct{}
+
+func (u *Untap) CanPay(c *model.Context) bool {
+	perm := c.Permanent
+	if !perm.Tapped {
+		return false
+	}
+	if !perm.SummoningSick {
+		return true
+	}
+	ch := c.Game.Characteristics(perm)
+	return !ch.Is(model.Creature) || ch.HasKeyword(model.Haste)
+}
+
+func (u *Untap) Pay(c *model.Context) []model.Command {
+	return []model.Command{&UntapCommand{Permanent: c.Permanent}}
+}
+
+// Mana implements model.Cost.
+var _ model.Cost = (*Mana)(nil)
+
+// Mana is a mana cost of an ability, paid from the mana pool.
+type Mana struct {
+	Cost model.ManaCost
+}
+
+func (m *Mana) CanPay(c *model.Context) bool {
+	_, ok := m.Cost.Pay(c.Player.ManaPool)
+	return ok
+}
+
+func (m *Mana) Pay(c *model.Context) []model.Command {
+	return []model.Command{&model.PayManaCommand{Cost: m.Cost, Player: c.Player}}
+}
+
+// Sacrifice implements model.Cost.
+var _ model.Cost = (*Sacrifice)(nil)
+
+// Sacrifice sacrifices the permanent with the ability when Self is set, and
+// otherwise a creature of the controller's choice matching Creature.
+type Sacrifice struct {
+	Self     bool
+	Creature Creatures
+}
+
+func (s *Sacrifice) candidates(c *model.Context) []any {
+	if s.Self {
+		return []any{c.Permanent}
+	}
+	var perms []any
+	for _, perm := range c.Player.BattleField {
+		ch := c.Game.Characteristics(perm)
+		if s.Creature.Match(c, perm, &ch) {
+			perms = append(perms, perm)
+		}
+	}
+	return perms
+}
+
+func (s *Sacrifice) CanPay(c *model.Context) bool {
+	return len(s.candidates(c)) > 0
+}
+
+func (s *Sacrifice) Pay(c *model.Context) []model.Command {
+	perms := s.candidates(c)
+	perm := perms[c.Game.Choose(c.Player, "sacrifice", perms)].(*model.Permanent)
+	return []model.Command{c.Game.PutIntoGraveyard(perm)}
+}
+
+// PayLife implements model.Cost.
+var _ model.Cost = (*PayLife)(nil)
+
+type PayLife struct {
+	N int
+}
+
+func (pl *PayLife) CanPay(c *model.Context) bool {
+	return c.Player.Life >= pl.N
+}
+
+func (pl *PayLife) Pay(c *model.Context) []model.Command {
+	return []model.Command{&LifeCommand{Player: c.Player, N: -pl.N}}
+}
+
+// Discard implements model.Cost.
+var _ model.Cost = (*Discard)(nil)
+
+// Discard discards a card of the controller's choice.
+type Discard struct{}
+
+func (d *Discard) CanPay(c *model.Context) bool {
+	return len(c.Player.Hand) > 0
+}
+
+func (d *Discard) Pay(c *model.Context) []model.Command {
+	i := c.Game.Choose(c.Player, "discard", cards(c.Player.Hand))
+	return []model.Command{&model.MoveCommand{
+		Game:     c.Game,
+		Player:   c.Player,
+		Card:     c.Player.Hand[i],
+		From:     model.HandZone,
+		To:       model.GraveyardZone,
+		Position: model.Bottom,
+	}}
+}
+
+// ExileFromGraveyard implements model.Cost.
+var _ model.Cost = (*ExileFromGraveyard)(nil)
+
+// ExileFromGraveyard exiles a card of the controller's choice from their
+// graveyard.
+type ExileFromGraveyard struct{}
+
+func (ex *ExileFromGraveyard) CanPay(c *model.Context) bool {
+	return len(c.Player.GraveYard) > 0
+}
+
+func (ex *ExileFromGraveyard) Pay(c *model.Context) []model.Command {
+	i := c.Game.Choose(c.Player, "exile from graveyard", cards(c.Player.GraveYard))
+	return []model.Command{&model.MoveCommand{
+		Game:     c.Game,
+		Player:   c.Player,
+		Card:     c.Player.GraveYard[i],
+		From:     model.GraveyardZone,
+		To:       model.ExileZone,
+		Position: model.Bottom,
+	}}
+}
+
+func cards(cs []*model.Object) []any {
+	var as []any
+	for _, c := range cs {
+		as = append(as, c)
+	}
+	return as
+}
+
+// RemoveCounters implements model.Cost.
+var _ model.Cost = (*RemoveCounters)(nil)
+
+// RemoveCounters removes N counters of a kind from the permanent with the
+// ability.
+type RemoveCounters struct {
+	Counter model.Counter
+	N       int
+}
+
+func (rc *RemoveCounters) CanPay(c *model.Context) bool {
+	return c.Permanent.Counters[rc.Counter] >= rc.N
+}
+
+func (rc *RemoveCounters) Pay(c *model.Context) []model.Command {
+	return []model.Command{&CounterCommand{Permanent: c.Permanent, Counter: rc.Counter, N: -rc.N}}
+}
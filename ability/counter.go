@@ -0,0 +1,88 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// CounterCommand implements model.Command.
+var _ model.Command = (*CounterCommand)(nil)
+
+// CounterCommand puts N counters of a kind on Permanent, or on Player when
+// set, or removes -N of them when N is negative.
+type CounterCommand struct {
+	Permanent *model.Permanent
+	Player    *model.Player
+	Counter   model.Counter
+	N         int
+
+	prev model.Counters
+}
+
+func (cc *CounterCommand) counters() *model.Counters {
+	if cc.Player != nil {
+		return &cc.Player.Counters
+	}
+	return &cc.Permanent.Counters
+}
+
+func (cc *CounterCommand) Execute() {
+	cs := cc.counters()
+	cc.prev = *cs
+	*cs = cs.Add(cc.Counter, cc.N)
+}
+
+func (cc *CounterCommand) Undo() {
+	*cc.counters() = cc.prev
+}
+
+// PutCounters implements model.Effect.
+var _ model.Effect = (*PutCounters)(nil)
+
+// PutCounters puts N counters of a kind on the permanent with the ability.
+type PutCounters struct {
+	Counter model.Counter
+	N       int
+}
+
+func (pc *PutCounters) Commands(c *model.Context) []model.Command {
+	return []model.Command{&CounterCommand{Permanent: c.Permanent, Counter: pc.Counter, N: pc.N}}
+}
+
+// Outlast returns the outlast ability with cost: "cost, {T}: Put a +1/+1
+// counter on this creature. Activate only as a sorcery."
+func Outlast(cost model.ManaCost) *Activated {
+	return &Activated{
+		Costs:        model.Costs{&Mana{Cost: cost}, tap},
+		Effect:       &PutCounters{Counter: model.PlusOneCounter, N: 1},
+		SorcerySpeed: true,
+	}
+}
+
+// Bolster implements model.Effect.
+var _ model.Effect = (*Bolster)(nil)
+
+// Bolster is "Bolster N": the controller chooses a creature with the least
+// toughness among those they control and puts N +1/+1 counters on it.
+type Bolster struct {
+	N int
+}
+
+func (b *Bolster) Commands(c *model.Context) []model.Command {
+	var least []any
+	toughness := 0
+	for _, perm := range c.Player.BattleField {
+		ch := c.Game.Characteristics(perm)
+		switch {
+		case !ch.Is(model.Creature):
+		case len(least) == 0 || ch.Toughness < toughness:
+			least, toughness = []any{perm}, ch.Toughness
+		case ch.Toughness == toughness:
+			least = append(least, perm)
+		}
+	}
+	if len(least) == 0 {
+		return nil
+	}
+	perm := least[c.Game.Choose(c.Player, "bolster", least)].(*model.Permanent)
+	return []model.Command{&CounterCommand{Permanent: perm, Counter: model.PlusOneCounter, N: b.N}}
+}
@@ -0,0 +1,81 @@
+package ability
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+func TestCounterCommand(t *testing.T) {
+	p := &model.Player{}
+	g := &model.Game{Players: []*model.Player{p}}
+	perm := &model.Permanent{Type: model.Creature, Card: g.NewObject(&model.Card{Name: "Bear", Type: model.Creature}, p)}
+	cp := g.Checkpoint()
+	g.Execute(&CounterCommand{Permanent: perm, Counter: model.PlusOneCounter, N: 2})
+	g.Execute(&CounterCommand{Permanent: perm, Counter: model.PlusOneCounter, N: -1})
+	g.Execute(&CounterCommand{Player: p, Counter: model.PoisonCounter, N: 3})
+	if !reflect.DeepEqual(perm.Counters, model.Counters{model.PlusOneCounter: 1}) || !reflect.DeepEqual(p.Counters, model.Counters{model.PoisonCounter: 3}) {
+		t.Errorf("counters %v and %v", perm.Counters, p.Counters)
+	}
+	g.Rollback(cp)
+	if perm.Counters != nil || p.Counters != nil {
+		t.Errorf("counters %v and %v after rollback", perm.Counters, p.Counters)
+	}
+}
+
+func TestOutlast(t *testing.T) {
+	p := &model.Player{}
+	g := &model.Game{Players: []*model.Player{p}, CurrentPart: model.FirstMainPhase}
+	outlast := Outlast(model.MustParseManaCost("{W}"))
+	c := g.NewObject(&model.Card{
+		Name:               "Ainok Bond-Kin",
+		Type:               model.Creature,
+		Power:              2,
+		Toughness:          1,
+		ActivatedAbilities: []model.ActivatedAbility{outlast},
+	}, p)
+	perm := &model.Permanent{Controller: p, Type: model.Creature, Card: c}
+	p.BattleField = []*model.Permanent{perm}
+	p.ManaPool = []model.Mana{model.White, model.White}
+
+	g.CurrentPart = model.BeginningOfCombatStep
+	if len(g.LegalActions(p)) != 0 {
+		t.Errorf("outlast can be activated in combat")
+	}
+	if err := g.Activate(p, perm, outlast); err == nil {
+		t.Errorf("activated outlast in combat")
+	}
+	g.CurrentPart = model.FirstMainPhase
+	if err := g.Activate(p, perm, outlast); err != nil {
+		t.Fatal(err)
+	}
+	g.Resolve()
+	if !perm.Tapped || g.Power(perm) != 3 || g.Toughness(perm) != 2 {
+		t.Errorf("tapped %t, %d/%d after outlast", perm.Tapped, g.Power(perm), g.Toughness(perm))
+	}
+}
+
+func TestBolster(t *testing.T) {
+	p := &model.Player{}
+	g := &model.Game{Players: []*model.Player{p}}
+	enter := func(name string, toughness int) *model.Permanent {
+		c := g.NewObject(&model.Card{Name: name, Type: model.Creature, Toughness: toughness}, p)
+		perm := &model.Permanent{Controller: p, Type: model.Creature, Card: c}
+		p.BattleField = append(p.BattleField, perm)
+		return perm
+	}
+	big := enter("Big", 3)
+	small := enter("Small", 3)
+	small.Counters = model.Counters{model.MinusOneCounter: 1}
+	c := &model.Context{Game: g, Player: p}
+	for _, cmd := range (&Bolster{N: 2}).Commands(c) {
+		g.Execute(cmd)
+	}
+	if big.Counters != nil || small.Counters[model.PlusOneCounter] != 2 {
+		t.Errorf("counters %v and %v", big.Counters, small.Counters)
+	}
+	if cmds := (&Bolster{N: 1}).Commands(&model.Context{Game: g, Player: &model.Player{}}); cmds != nil {
+		t.Errorf("bolstered without creatures: %v", cmds)
+	}
+}
@@ -0,0 +1,54 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// Loyalty implements model.Cost.
+var _ model.Cost = (*Loyalty)(nil)
+
+// Loyalty is the cost of a loyalty ability: putting N loyalty counters on the
+// planeswalker, or removing -N when N is negative. It can be paid once per
+// turn per permanent, which is how loyalty abilities are limited to one each
+// turn.
+type Loyalty struct {
+	N int
+}
+
+func (l *Loyalty) CanPay(c *model.Context) bool {
+	return !c.Permanent.LoyaltyActivated && c.Permanent.Counters[model.LoyaltyCounter]+l.N >= 0
+}
+
+func (l *Loyalty) Pay(c *model.Context) []model.Command {
+	return []model.Command{
+		&CounterCommand{Permanent: c.Permanent, Counter: model.LoyaltyCounter, N: l.N},
+		&loyaltyActivatedCommand{Permanent: c.Permanent},
+	}
+}
+
+// LoyaltyAbility returns the loyalty ability "+N: effect", or "−N: effect"
+// when n is negative, which may only be activated as a sorcery.
+func LoyaltyAbility(n int, effect model.Effect) *Activated {
+	return &Activated{
+		Costs:        model.Costs{&Loyalty{N: n}},
+		Effect:       effect,
+		SorcerySpeed: true,
+	}
+}
+
+// loyaltyActivatedCommand implements model.Command.
+var _ model.Command = (*loyaltyActivatedCommand)(nil)
+
+// loyaltyActivatedCommand notes that a loyalty ability of Permanent was
+// activated this turn.
+type loyaltyActivatedCommand struct {
+	Permanent *model.Permanent
+}
+
+func (lc *loyaltyActivatedCommand) Execute() {
+	lc.Permanent.LoyaltyActivated = true
+}
+
+func (lc *loyaltyActivatedCommand) Undo() {
+	lc.Permanent.LoyaltyActivated = false
+}
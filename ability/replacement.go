@@ -0,0 +1,84 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// EnterTapped implements model.ReplacementEffect.
+var _ model.ReplacementEffect = (*EnterTapped)(nil)
+
+// EnterTapped makes the Affected creatures enter the battlefield tapped.
+type EnterTapped struct {
+	Affected Creatures
+}
+
+func (et *EnterTapped) Replaces(e *model.Event, c *model.Context) bool {
+	if e.Kind != model.EntersBattlefield || e.Tapped {
+		return false
+	}
+	ch := c.Game.Characteristics(e.Permanent)
+	// The permanent is not on the battlefield yet.
+	ch.Controller = e.Player
+	return et.Affected.Match(c, e.Permanent, &ch)
+}
+
+func (et *EnterTapped) Replace(e *model.Event, c *model.Context) {
+	e.Tapped = true
+}
+
+// ExileInsteadOfDying implements model.ReplacementEffect.
+var _ model.ReplacementEffect = (*ExileInsteadOfDying)(nil)
+
+// ExileInsteadOfDying exiles the Affected creatures that would die.
+type ExileInsteadOfDying struct {
+	Affected Creatures
+}
+
+func (ex *ExileInsteadOfDying) Replaces(e *model.Event, c *model.Context) bool {
+	if e.Kind != model.Dies {
+		return false
+	}
+	ch := c.Game.Characteristics(e.Permanent)
+	return ex.Affected.Match(c, e.Permanent, &ch)
+}
+
+func (ex *ExileInsteadOfDying) Replace(e *model.Event, c *model.Context) {
+	e.Kind = model.Exiled
+}
+
+// SkipDraw implements model.ReplacementEffect.
+var _ model.ReplacementEffect = (*SkipDraw)(nil)
+
+// SkipDraw skips the draws of its controller.
+type SkipDraw struct{}
+
+func (sd *SkipDraw) Replaces(e *model.Event, c *model.Context) bool {
+	return e.Kind == model.Draws && e.Player == c.Player
+}
+
+func (sd *SkipDraw) Replace(e *model.Event, c *model.Context) {
+	e.Cancelled = true
+}
+
+// PreventDamage implements model.ReplacementEffect.
+var _ model.ReplacementEffect = (*PreventDamage)(nil)
+
+// PreventDamage prevents N of the damage that would be dealt to its
+// controller by each source, or all of it when N is zero.
+type PreventDamage struct {
+	N int
+}
+
+func (pd *PreventDamage) Replaces(e *model.Event, c *model.Context) bool {
+	return (e.Kind == model.DealsDamage || e.Kind == model.DealsCombatDamage) &&
+		e.Target == c.Player && e.Amount > 0
+}
+
+func (pd *PreventDamage) Replace(e *model.Event, c *model.Context) {
+	if pd.N == 0 || pd.N >= e.Amount {
+		e.Amount = 0
+		e.Cancelled = true
+		return
+	}
+	e.Amount -= pd.N
+}
@@ -0,0 +1,206 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// Creatures selects the creatures a static ability affects.
+type Creatures struct {
+	// Self selects only the permanent with the ability.
+	Self bool
+	// Other excludes the permanent with the ability.
+	Other bool
+	// You selects only the creatures its controller controls.
+	You bool
+	// Opponents selects only the creatures its controller does not control.
+	Opponents bool
+	// Tokens selects only creature tokens.
+	Tokens bool
+	// SubTypes, when set, selects only creatures with one of them.
+	SubTypes []model.Type
+}
+
+func (cs *Creatures) Match(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	switch {
+	case !ch.Is(model.Creature):
+		return false
+	case cs.Self && perm != c.Permanent:
+		return false
+	case cs.Other && perm == c.Permanent:
+		return false
+	case cs.You && ch.Controller != c.Player:
+		return false
+	case cs.Opponents && ch.Controller == c.Player:
+		return false
+	case cs.Tokens && !perm.Card.Token:
+		return false
+	}
+	if len(cs.SubTypes) == 0 {
+		return true
+	}
+	for _, t := range cs.SubTypes {
+		if ch.Is(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pump implements model.StaticAbility.
+var _ model.StaticAbility = (*Pump)(nil)
+
+// Pump gives the Affected creatures +Power/+Toughness, as long as If holds.
+type Pump struct {
+	Affected  Creatures
+	Power     int
+	Toughness int
+	// If is nil for an unconditional pump.
+	If func(c *model.Context) bool
+}
+
+func (p *Pump) Layer() model.Layer {
+	return model.PTModifyingLayer
+}
+
+func (p *Pump) Affects(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	return (p.If == nil || p.If(c)) && p.Affected.Match(c, perm, ch)
+}
+
+func (p *Pump) Apply(ch *model.Characteristics) {
+	ch.Power += p.Power
+	ch.Toughness += p.Toughness
+}
+
+// Grant implements model.StaticAbility.
+var _ model.StaticAbility = (*Grant)(nil)
+
+// Grant gives the Affected creatures Keyword, as long as If holds.
+type Grant struct {
+	Affected Creatures
+	Keyword  model.Keyword
+	// If is nil for an unconditional grant.
+	If func(c *model.Context) bool
+}
+
+func (g *Grant) Layer() model.Layer {
+	return model.AbilityLayer
+}
+
+func (g *Grant) Affects(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	return (g.If == nil || g.If(c)) && g.Affected.Match(c, perm, ch)
+}
+
+func (g *Grant) Apply(ch *model.Characteristics) {
+	if !ch.HasKeyword(g.Keyword) {
+		ch.Keywords = append(ch.Keywords, g.Keyword)
+	}
+}
+
+// YouControlColor returns a condition that holds while the controller
+// controls a permanent of one of ms. Colors are those of the mana costs of
+// the permanents' cards, so that the condition does not depend on other
+// continuous effects.
+func YouControlColor(ms ...model.Mana) func(c *model.Context) bool {
+	return func(c *model.Context) bool {
+		for _, perm := range c.Player.BattleField {
+			for _, m := range ms {
+				if perm.Card.Cost.HasColor(m) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// GainUntilEndOfTurn implements model.Effect.
+var _ model.Effect = (*GainUntilEndOfTurn)(nil)
+
+// GainUntilEndOfTurn gives the Affected creatures the controller's choice of
+// Keywords until end of turn.
+type GainUntilEndOfTurn struct {
+	Affected Creatures
+	Keywords []model.Keyword
+}
+
+func (ge *GainUntilEndOfTurn) Commands(c *model.Context) []model.Command {
+	var options []any
+	for _, k := range ge.Keywords {
+		options = append(options, k)
+	}
+	k := ge.Keywords[c.Game.Choose(c.Player, "keyword", options)]
+	return []model.Command{&model.AddEffectCommand{Game: c.Game, Effect: &model.ContinuousEffect{
+		StaticAbility: &Grant{Affected: ge.Affected, Keyword: k},
+		Context:       c,
+	}}}
+}
+
+// Become implements model.Effect.
+var _ model.Effect = (*Become)(nil)
+
+// Become makes the permanent with the ability a Power/Toughness creature of
+// SubTypes and Colors with Keywords until end of turn, e.g. "Until end of
+// turn, this becomes a 4/4 red Dragon creature with flying." It stops being
+// of its other types.
+type Become struct {
+	SubTypes  []model.Type
+	Colors    []model.Mana
+	Keywords  []model.Keyword
+	Power     int
+	Toughness int
+}
+
+func (b *Become) Commands(c *model.Context) []model.Command {
+	sas := []model.StaticAbility{&becomeType{b}, &becomeColors{b}}
+	for _, k := range b.Keywords {
+		sas = append(sas, &Grant{Affected: Creatures{Self: true}, Keyword: k})
+	}
+	sas = append(sas, &becomePT{b})
+	var cmds []model.Command
+	for _, sa := range sas {
+		cmds = append(cmds, &model.AddEffectCommand{Game: c.Game, Effect: &model.ContinuousEffect{
+			StaticAbility: sa,
+			Context:       c,
+		}})
+	}
+	return cmds
+}
+
+// becomeType is the part of Become in the type layer.
+type becomeType struct{ *Become }
+
+func (bt *becomeType) Layer() model.Layer { return model.TypeLayer }
+
+func (bt *becomeType) Affects(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	return perm == c.Permanent
+}
+
+func (bt *becomeType) Apply(ch *model.Characteristics) {
+	ch.Type, ch.SubTypes = model.Creature, bt.SubTypes[:len(bt.SubTypes):len(bt.SubTypes)]
+}
+
+// becomeColors is the part of Become in the color layer.
+type becomeColors struct{ *Become }
+
+func (bc *becomeColors) Layer() model.Layer { return model.ColorLayer }
+
+func (bc *becomeColors) Affects(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	return perm == c.Permanent
+}
+
+func (bc *becomeColors) Apply(ch *model.Characteristics) {
+	ch.Colors = bc.Colors[:len(bc.Colors):len(bc.Colors)]
+}
+
+// becomePT is the part of Become setting power and toughness.
+type becomePT struct{ *Become }
+
+func (bp *becomePT) Layer() model.Layer { return model.PTSettingLayer }
+
+func (bp *becomePT) Affects(c *model.Context, perm *model.Permanent, ch *model.Characteristics) bool {
+	return perm == c.Permanent
+}
+
+func (bp *becomePT) Apply(ch *model.Characteristics) {
+	ch.Power, ch.Toughness = bp.Power, bp.Toughness
+}
@@ -0,0 +1,121 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// AnyTarget implements model.TargetSpec.
+var _ model.TargetSpec = (*AnyTarget)(nil)
+
+// AnyTarget is "any target": a player, a creature or a planeswalker.
+type AnyTarget struct{}
+
+func (at *AnyTarget) Legal(c *model.Context, t model.Target) bool {
+	if t.Player != nil {
+		return true
+	}
+	ch := c.Game.Characteristics(t.Permanent)
+	return ch.Is(model.Creature) || ch.Is(model.Planeswalker)
+}
+
+// TargetCreature implements model.TargetSpec.
+var _ model.TargetSpec = (*TargetCreature)(nil)
+
+// TargetCreature is "target creature", limited to those matching Creature,
+// e.g. Creatures{Opponents: true} for "target creature you don't control".
+type TargetCreature struct {
+	Creature Creatures
+}
+
+func (tc *TargetCreature) Legal(c *model.Context, t model.Target) bool {
+	if t.Permanent == nil {
+		return false
+	}
+	ch := c.Game.Characteristics(t.Permanent)
+	return tc.Creature.Match(c, t.Permanent, &ch)
+}
+
+// TargetPlayer implements model.TargetSpec.
+var _ model.TargetSpec = (*TargetPlayer)(nil)
+
+// TargetPlayer is "target player", or "target opponent" when Opponent is set.
+type TargetPlayer struct {
+	Opponent bool
+}
+
+func (tp *TargetPlayer) Legal(c *model.Context, t model.Target) bool {
+	return t.Player != nil && !(tp.Opponent && t.Player == c.Player)
+}
+
+// Damage implements model.Effect and model.Targeted.
+var (
+	_ model.Effect   = (*Damage)(nil)
+	_ model.Targeted = (*Damage)(nil)
+)
+
+// Damage deals N damage to a target matching To.
+type Damage struct {
+	N  int
+	To model.TargetSpec
+}
+
+func (d *Damage) Targets() []model.TargetSpec {
+	return []model.TargetSpec{d.To}
+}
+
+func (d *Damage) Commands(c *model.Context) []model.Command {
+	t := c.Targets[0]
+	if t.IsZero() {
+		return nil
+	}
+	return c.Game.Damage(model.Event{
+		Kind:            model.DealsDamage,
+		Player:          c.Player,
+		Permanent:       c.Permanent,
+		Card:            c.Card,
+		Target:          t.Player,
+		TargetPermanent: t.Permanent,
+		Amount:          d.N,
+	})
+}
+
+// ChooseDiscard implements model.Effect and model.Targeted.
+var (
+	_ model.Effect   = (*ChooseDiscard)(nil)
+	_ model.Targeted = (*ChooseDiscard)(nil)
+)
+
+// ChooseDiscard is "Target opponent reveals their hand. You choose a card
+// from it matching Card. That player discards that card."
+type ChooseDiscard struct {
+	Card func(c *model.Card) bool
+}
+
+func (cd *ChooseDiscard) Targets() []model.TargetSpec {
+	return []model.TargetSpec{&TargetPlayer{Opponent: true}}
+}
+
+func (cd *ChooseDiscard) Commands(c *model.Context) []model.Command {
+	p := c.Targets[0].Player
+	if p == nil {
+		return nil
+	}
+	var options []*model.Object
+	for _, card := range p.Hand {
+		if cd.Card(card.Card) {
+			options = append(options, card)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	card := options[c.Game.Choose(c.Player, "discard", cards(options))]
+	return []model.Command{&model.MoveCommand{
+		Game:     c.Game,
+		Player:   p,
+		Card:     card,
+		From:     model.HandZone,
+		To:       model.GraveyardZone,
+		Position: model.Bottom,
+	}}
+}
@@ -0,0 +1,27 @@
+package ability
+
+import (
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+func TestDamageFromSpell(t *testing.T) {
+	p0, p1 := &model.Player{Life: 20}, &model.Player{Life: 20}
+	g := &model.Game{Players: []*model.Player{p0, p1}}
+	bolt := g.NewObject(&model.Card{
+		Name:     "Lifelink Bolt",
+		Type:     model.Instant,
+		Keywords: []model.Keyword{model.Lifelink},
+		Spell:    &Damage{N: 3, To: &TargetPlayer{Opponent: true}},
+	}, p0)
+	p0.Hand = []*model.Object{bolt}
+	if err := g.Cast(p0, bolt, model.Target{Player: p1}); err != nil {
+		t.Fatal(err)
+	}
+	g.Resolve()
+	// The spell is the source of the damage, so its lifelink applies.
+	if p0.Life != 23 || p1.Life != 17 {
+		t.Errorf("life totals %d and %d, want 23 and 17", p0.Life, p1.Life)
+	}
+}
@@ -0,0 +1,60 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// CreateToken implements model.Effect.
+var _ model.Effect = (*CreateToken)(nil)
+
+// CreateToken creates N tokens of Token under the controller's control.
+// Token is the definition of the tokens: a card with Token set, whose Colors
+// give their colors.
+type CreateToken struct {
+	Token *model.Card
+	N     int
+}
+
+func (ct *CreateToken) Commands(c *model.Context) []model.Command {
+	var cmds []model.Command
+	for i := 0; i < ct.N; i++ {
+		token := c.Game.Entering(c.Player, &model.Object{Card: ct.Token, Owner: c.Player})
+		cmds = append(cmds, &CreateTokenCommand{Game: c.Game, Player: c.Player, Permanent: token})
+	}
+	return cmds
+}
+
+// CreateTokenCommand implements model.Command.
+var _ model.Command = (*CreateTokenCommand)(nil)
+
+// CreateTokenCommand puts Permanent, a new token, onto Player's battlefield,
+// and counts it in Player's TokensCreated. Get Permanent from
+// model.Game.Entering, which applies the replacement effects to its entering.
+// The token gets its ID and timestamp when the command is executed, so that
+// rolling it back and redoing it gives the same ones.
+type CreateTokenCommand struct {
+	Game      *model.Game
+	Player    *model.Player
+	Permanent *model.Permanent
+
+	lastID, timestamp int
+}
+
+func (cc *CreateTokenCommand) Execute() {
+	g := cc.Game
+	cc.lastID = g.LastID
+	g.LastID++
+	cc.Permanent.Card.ID = g.LastID
+	cc.timestamp = g.Enter(cc.Player, cc.Permanent)
+	cc.Player.TokensCreated++
+}
+
+func (cc *CreateTokenCommand) Events() []model.Event {
+	return []model.Event{{Kind: model.EntersBattlefield, Player: cc.Player, Permanent: cc.Permanent}}
+}
+
+func (cc *CreateTokenCommand) Undo() {
+	cc.Player.TokensCreated--
+	cc.Game.Leave(cc.Player, cc.Permanent, cc.timestamp)
+	cc.Game.LastID = cc.lastID
+}
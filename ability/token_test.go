@@ -0,0 +1,82 @@
+package ability
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+func TestCreateToken(t *testing.T) {
+	p := &model.Player{}
+	g := &model.Game{Players: []*model.Player{p}}
+	warrior := &model.Card{
+		Name:      "Warrior Token",
+		Type:      model.Creature,
+		SubTypes:  []model.Type{model.Worrier},
+		Colors:    []model.Mana{model.White},
+		Power:     1,
+		Toughness: 1,
+		Keywords:  []model.Keyword{model.Vigilance},
+		Token:     true,
+	}
+	bear := &model.Permanent{Controller: p, Type: model.Creature, Card: g.NewObject(&model.Card{Name: "Bear", Type: model.Creature}, p)}
+	p.BattleField = []*model.Permanent{bear}
+	entered := 0
+	g.Listen(func(g *model.Game, e *model.Event) {
+		if e.Kind == model.EntersBattlefield {
+			entered++
+		}
+	})
+
+	lastID, timestamp := g.LastID, g.Timestamp
+	cp := g.Checkpoint()
+	c := &model.Context{Game: g, Player: p}
+	if cmds := (&CreateToken{Token: warrior}).Commands(c); len(cmds) != 0 {
+		t.Errorf("%d commands creating no tokens", len(cmds))
+	}
+	for _, cmd := range (&CreateToken{Token: warrior, N: 2}).Commands(c) {
+		g.Execute(cmd)
+	}
+	if len(p.BattleField) != 3 || p.TokensCreated != 2 || entered != 2 {
+		t.Fatalf("battlefield %v, %d tokens created, %d entered", p.BattleField, p.TokensCreated, entered)
+	}
+	tokens := p.BattleField[1:]
+	if tokens[0].Card == tokens[1].Card || tokens[0].Card.ID == tokens[1].Card.ID {
+		t.Errorf("tokens share the object %v", tokens[0].Card)
+	}
+	ch := g.Characteristics(tokens[0])
+	if !ch.HasColor(model.White) || !ch.Is(model.Worrier) || !ch.HasKeyword(model.Vigilance) || ch.Power != 1 || !tokens[0].SummoningSick {
+		t.Errorf("token characteristics %+v", ch)
+	}
+	ts := &Creatures{Tokens: true}
+	if bch := g.Characteristics(bear); ts.Match(c, bear, &bch) || !ts.Match(c, tokens[0], &ch) {
+		t.Errorf("Creatures{Tokens: true} does not match the token only")
+	}
+
+	// A token leaving the battlefield ceases to exist.
+	g.Execute(&model.MoveCommand{Game: g, Player: p, Card: tokens[0].Card, Permanent: tokens[0], From: model.BattlefieldZone, To: model.GraveyardZone, Position: model.Bottom})
+	if len(p.BattleField) != 2 || len(p.GraveYard) != 0 {
+		t.Errorf("battlefield %v and graveyard %v", p.BattleField, p.GraveYard)
+	}
+
+	ids := []int{tokens[0].Card.ID, tokens[1].Card.ID}
+	timestamps := []int{tokens[0].Timestamp, tokens[1].Timestamp}
+	g.Rollback(cp)
+	if !reflect.DeepEqual(p.BattleField, []*model.Permanent{bear}) || p.TokensCreated != 0 {
+		t.Errorf("battlefield %v and %d tokens created after rollback", p.BattleField, p.TokensCreated)
+	}
+	if g.LastID != lastID || g.Timestamp != timestamp {
+		t.Errorf("last ID %d and timestamp %d after rollback, want %d and %d", g.LastID, g.Timestamp, lastID, timestamp)
+	}
+	g.Redo(cp + 2)
+	if !reflect.DeepEqual(p.BattleField, []*model.Permanent{bear, tokens[0], tokens[1]}) || p.TokensCreated != 2 {
+		t.Errorf("battlefield %v and %d tokens created after redo", p.BattleField, p.TokensCreated)
+	}
+	if got := []int{tokens[0].Card.ID, tokens[1].Card.ID}; !reflect.DeepEqual(got, ids) {
+		t.Errorf("IDs %v after redo, want %v", got, ids)
+	}
+	if got := []int{tokens[0].Timestamp, tokens[1].Timestamp}; !reflect.DeepEqual(got, timestamps) {
+		t.Errorf("timestamps %v after redo, want %v", got, timestamps)
+	}
+}
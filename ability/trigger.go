@@ -0,0 +1,58 @@
+package ability
+
+import (
+	"github.com/kkishi/mtg/model"
+)
+
+// When implements model.TriggeredAbility and model.ModalEffect.
+var (
+	_ model.TriggeredAbility = (*When)(nil)
+	_ model.ModalEffect      = (*When)(nil)
+)
+
+// When is a triggered ability of a permanent: "When this enters the
+// battlefield, ...", "Whenever this attacks, ...". Events about a permanent
+// trigger it when the permanent is the one with the ability, events about a
+// player when the player is its controller.
+type When struct {
+	Event model.EventKind
+	// If is an intervening "if" clause. It is checked both when the ability
+	// would trigger and when it resolves. Nil means no condition.
+	If   func(c *model.Context) bool
+	Then model.Effect
+}
+
+func (w *When) Triggers(e *model.Event, c *model.Context) bool {
+	if e.Kind != w.Event {
+		return false
+	}
+	if e.Permanent != nil {
+		if e.Permanent != c.Permanent {
+			return false
+		}
+	} else if e.Player != c.Player {
+		return false
+	}
+	return w.If == nil || w.If(c)
+}
+
+// Modal returns Then when it is modal, e.g. "When this enters the
+// battlefield, choose one —".
+func (w *When) Modal() *model.Modal {
+	if me, ok := w.Then.(model.ModalEffect); ok {
+		return me.Modal()
+	}
+	return nil
+}
+
+func (w *When) Commands(c *model.Context) []model.Command {
+	if w.If != nil && !w.If(c) {
+		return nil
+	}
+	return w.Then.Commands(c)
+}
+
+// Raid is the condition of raid abilities: the controller attacked this turn.
+func Raid(c *model.Context) bool {
+	return c.Player.Attacked
+}
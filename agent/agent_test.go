@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/kkishi/mtg/goldfish"
+	"github.com/kkishi/mtg/model"
+)
+
+func newGame(t *testing.T, r *rand.Rand, agents ...model.Agent) *model.Game {
+	t.Helper()
+	g := &model.Game{}
+	for i, a := range agents {
+		p := &model.Player{First: i == 0, Life: 20, Agent: a}
+		p.Library = goldfish.MakeLibrary(g, p, goldfish.MarduWorrier)
+		g.Players = append(g.Players, p)
+	}
+	if err := g.DrawOpeningHands(r); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// play plays turns of g until the first player has taken n turns.
+func play(t *testing.T, g *model.Game, n int) {
+	t.Helper()
+	g.Start()
+	for g.Players[0].Turn <= n && !g.Over() {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLondonMulligan(t *testing.T) {
+	s := &Scripted{Mulligans: []bool{true, true, false}}
+	g := newGame(t, rand.New(rand.NewSource(1)), s, model.Passive{})
+	p := g.Players[0]
+	if len(p.Hand) != 5 || len(p.Library) != 55 {
+		t.Errorf("hand %d and library %d after two mulligans, want 5 and 55", len(p.Hand), len(p.Library))
+	}
+	if len(g.Players[1].Hand) != 7 {
+		t.Errorf("opponent kept %d cards, want 7", len(g.Players[1].Hand))
+	}
+}
+
+func TestGreedy(t *testing.T) {
+	g := newGame(t, rand.New(rand.NewSource(1)), Greedy{}, model.Passive{})
+	play(t, g, 4)
+	p := g.Players[0]
+	if n := len(p.BattleField); n < 5 {
+		t.Errorf("%d permanents after four turns: %v", n, p.BattleField)
+	}
+	for _, perm := range p.BattleField {
+		if perm.Card.Type != model.Land {
+			return
+		}
+	}
+	t.Errorf("no spell cast in four turns")
+}
+
+func TestRandom(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		g := newGame(t, r, &Random{R: r}, &Random{R: r})
+		play(t, g, 10)
+	}
+}
+
+func TestRandomModes(t *testing.T) {
+	r := &Random{R: rand.New(rand.NewSource(1))}
+	for i := 0; i < 20; i++ {
+		if is := r.ChooseModes(nil, nil, []string{"a", "b", "c"}, 1, 3); !slices.IsSorted(is) {
+			t.Errorf("modes %v are not in ascending order", is)
+		}
+	}
+}
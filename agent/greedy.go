@@ -0,0 +1,214 @@
+package agent
+
+import (
+	"sort"
+
+	"github.com/kkishi/mtg/ability"
+	"github.com/kkishi/mtg/model"
+)
+
+// Greedy implements model.Agent.
+var _ model.Agent = (*Greedy)(nil)
+
+// Greedy plays by simple heuristics: it keeps hands with two to five lands,
+// plays a land whenever it can, casts the most expensive spell it can pay
+// for, tapping lands as needed, attacks with everything and blocks when the
+// blocker survives.
+type Greedy struct{}
+
+func lands(cs []*model.Object) int {
+	n := 0
+	for _, c := range cs {
+		if c.Type == model.Land {
+			n++
+		}
+	}
+	return n
+}
+
+func (Greedy) Mulligan(g *model.Game, p *model.Player) bool {
+	n := lands(p.Hand)
+	return n < 2 || n > 5
+}
+
+// Priority plays a land, or else takes the next step to cast the most
+// expensive spell that the mana in p's pool and untapped lands pays for:
+// activating a mana ability, or casting it once the mana is in the pool.
+func (gr Greedy) Priority(g *model.Game, p *model.Player) model.Action {
+	if g.SorcerySpeed(p) && p.LandsPlayed == 0 {
+		for _, c := range p.Hand {
+			if c.Type == model.Land {
+				return model.Action{Card: c}
+			}
+		}
+	}
+	var best model.Action
+	var taps []model.Action
+	for _, c := range p.Hand {
+		if c.Type == model.Land || c.Type != model.Instant && !g.SorcerySpeed(p) {
+			continue
+		}
+		if best.Card != nil && c.Cost.Value() <= best.Card.Cost.Value() {
+			continue
+		}
+		modes, targets, ok := gr.modes(g, p, c.Spell)
+		if !ok {
+			continue
+		}
+		if ts, ok := gr.plan(g, p, c.Cost); ok {
+			best, taps = model.Action{Card: c, Targets: targets, Modes: modes}, ts
+		}
+	}
+	if len(taps) > 0 {
+		return taps[0]
+	}
+	return best
+}
+
+// modes chooses the first modes of a modal e for which there are targets, as
+// many as it may, and targets for them.
+func (gr Greedy) modes(g *model.Game, p *model.Player, e model.Effect) ([]int, []model.Target, bool) {
+	m, ok := e.(*model.Modal)
+	if !ok {
+		ts, ok := gr.targets(g, p, e)
+		return nil, ts, ok
+	}
+	var modes []int
+	var targets []model.Target
+	for i, mode := range m.Modes {
+		if len(modes) == m.Max {
+			break
+		}
+		if ts, ok := gr.targets(g, p, mode.Effect); ok {
+			modes, targets = append(modes, i), append(targets, ts...)
+		}
+	}
+	return modes, targets, len(modes) >= m.Min
+}
+
+// targets chooses a target for each target of e.
+func (gr Greedy) targets(g *model.Game, p *model.Player, e model.Effect) ([]model.Target, bool) {
+	t, ok := e.(model.Targeted)
+	if !ok {
+		return nil, true
+	}
+	var ts []model.Target
+	for _, spec := range t.Targets() {
+		legal := g.LegalTargets(&model.Context{Game: g, Player: p}, spec)
+		if len(legal) == 0 {
+			return nil, false
+		}
+		ts = append(ts, legal[gr.ChooseTarget(g, p, legal)])
+	}
+	return ts, true
+}
+
+// plan returns the mana abilities of p's untapped lands to activate to pay
+// cost along with p's mana pool, and whether there are such.
+func (Greedy) plan(g *model.Game, p *model.Player, cost model.ManaCost) ([]model.Action, bool) {
+	var sources []*model.Permanent
+	var abilities [][]*ability.ManaAbility
+	for _, perm := range p.BattleField {
+		c := &model.Context{Game: g, Player: p, Permanent: perm}
+		var mas []*ability.ManaAbility
+		for _, aa := range perm.Card.ActivatedAbilities {
+			if ma, ok := aa.(*ability.ManaAbility); ok && ma.Cost().CanPay(c) {
+				mas = append(mas, ma)
+			}
+		}
+		if len(mas) > 0 {
+			sources = append(sources, perm)
+			abilities = append(abilities, mas)
+		}
+	}
+	var taps []model.Action
+	var search func(i int, pool []model.Mana) bool
+	search = func(i int, pool []model.Mana) bool {
+		if _, ok := cost.Pay(pool); ok {
+			return true
+		}
+		if i == len(sources) || len(taps) == cost.Value() {
+			return false
+		}
+		for _, ma := range abilities[i] {
+			taps = append(taps, model.Action{Ability: ma, Permanent: sources[i]})
+			if search(i+1, append(pool[:len(pool):len(pool)], ma.Mana)) {
+				return true
+			}
+			taps = taps[:len(taps)-1]
+		}
+		return search(i+1, pool)
+	}
+	return taps, search(0, p.ManaPool)
+}
+
+// ChooseCards gives up lands when p has more than three, and otherwise the
+// most expensive cards.
+func (Greedy) ChooseCards(g *model.Game, p *model.Player, prompt string, n int) []*model.Object {
+	cs := append([]*model.Object(nil), p.Hand...)
+	landsFirst := lands(cs) > 3
+	sort.SliceStable(cs, func(i, j int) bool {
+		if li, lj := cs[i].Type == model.Land, cs[j].Type == model.Land; li != lj {
+			return li == landsFirst
+		}
+		return cs[i].Cost.Value() > cs[j].Cost.Value()
+	})
+	return cs[:n]
+}
+
+// ChooseTarget prefers opponents and their permanents.
+func (Greedy) ChooseTarget(g *model.Game, p *model.Player, legal []model.Target) int {
+	for i, t := range legal {
+		if t.Player != nil && t.Player != p || t.Permanent != nil && g.Controller(t.Permanent) != p {
+			return i
+		}
+	}
+	return 0
+}
+
+func (Greedy) ChooseModes(g *model.Game, p *model.Player, modes []string, min, max int) []int {
+	var is []int
+	for i := 0; i < max && i < len(modes); i++ {
+		is = append(is, i)
+	}
+	return is
+}
+
+// DeclareAttackers attacks an opponent with every creature.
+func (gr Greedy) DeclareAttackers(g *model.Game, p *model.Player, attackers []*model.Permanent, defenders []model.Target) []model.Attack {
+	d := defenders[gr.ChooseTarget(g, p, defenders)]
+	var as []model.Attack
+	for _, a := range attackers {
+		as = append(as, model.Attack{Attacker: a, Defender: d})
+	}
+	return as
+}
+
+// DeclareBlockers blocks each attacker with a creature it does not kill, if
+// there is one.
+func (Greedy) DeclareBlockers(g *model.Game, p *model.Player, attacks []model.Attack, blockers []*model.Permanent) []model.Block {
+	var bs []model.Block
+	used := map[*model.Permanent]bool{}
+	for _, a := range attacks {
+		for _, b := range blockers {
+			if !used[b] && g.Toughness(b)-b.Damage > g.Power(a.Attacker) {
+				used[b] = true
+				bs = append(bs, model.Block{Blocker: b, Attacker: a.Attacker})
+				break
+			}
+		}
+	}
+	return bs
+}
+
+// OrderBlockers orders the blockers by toughness, so that the damage kills as
+// many as possible.
+func (Greedy) OrderBlockers(g *model.Game, p *model.Player, attacker *model.Permanent, blockers []*model.Permanent) []*model.Permanent {
+	o := append([]*model.Permanent(nil), blockers...)
+	sort.SliceStable(o, func(i, j int) bool { return g.Toughness(o[i]) < g.Toughness(o[j]) })
+	return o
+}
+
+func (Greedy) Choose(g *model.Game, p *model.Player, prompt string, options []any) int {
+	return 0
+}
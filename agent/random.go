@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/kkishi/mtg/model"
+)
+
+// Random implements model.Agent.
+var _ model.Agent = (*Random)(nil)
+
+// Random makes uniformly random legal decisions with R. It mulligans a
+// quarter of the time.
+type Random struct {
+	R *rand.Rand
+}
+
+func (r *Random) Mulligan(g *model.Game, p *model.Player) bool {
+	return r.R.Intn(4) == 0
+}
+
+// Priority takes one of the legal actions or passes, each as likely.
+func (r *Random) Priority(g *model.Game, p *model.Player) model.Action {
+	as := g.LegalActions(p)
+	if i := r.R.Intn(len(as) + 1); i < len(as) {
+		return as[i]
+	}
+	return model.Action{}
+}
+
+func (r *Random) ChooseCards(g *model.Game, p *model.Player, prompt string, n int) []*model.Object {
+	var cs []*model.Object
+	for _, i := range r.R.Perm(len(p.Hand))[:n] {
+		cs = append(cs, p.Hand[i])
+	}
+	return cs
+}
+
+func (r *Random) ChooseTarget(g *model.Game, p *model.Player, legal []model.Target) int {
+	return r.R.Intn(len(legal))
+}
+
+func (r *Random) ChooseModes(g *model.Game, p *model.Player, modes []string, min, max int) []int {
+	is := r.R.Perm(len(modes))[:min+r.R.Intn(max-min+1)]
+	slices.Sort(is)
+	return is
+}
+
+// DeclareAttackers attacks with each creature half of the time.
+func (r *Random) DeclareAttackers(g *model.Game, p *model.Player, attackers []*model.Permanent, defenders []model.Target) []model.Attack {
+	var as []model.Attack
+	for _, a := range attackers {
+		if r.R.Intn(2) == 0 {
+			as = append(as, model.Attack{Attacker: a, Defender: defenders[r.R.Intn(len(defenders))]})
+		}
+	}
+	return as
+}
+
+// DeclareBlockers blocks with each creature half of the time.
+func (r *Random) DeclareBlockers(g *model.Game, p *model.Player, attacks []model.Attack, blockers []*model.Permanent) []model.Block {
+	var bs []model.Block
+	if len(attacks) == 0 {
+		return nil
+	}
+	for _, b := range blockers {
+		if r.R.Intn(2) == 0 {
+			bs = append(bs, model.Block{Blocker: b, Attacker: attacks[r.R.Intn(len(attacks))].Attacker})
+		}
+	}
+	return bs
+}
+
+func (r *Random) OrderBlockers(g *model.Game, p *model.Player, attacker *model.Permanent, blockers []*model.Permanent) []*model.Permanent {
+	o := append([]*model.Permanent(nil), blockers...)
+	r.R.Shuffle(len(o), func(i, j int) { o[i], o[j] = o[j], o[i] })
+	return o
+}
+
+func (r *Random) Choose(g *model.Game, p *model.Player, prompt string, options []any) int {
+	return r.R.Intn(len(options))
+}
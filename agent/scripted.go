@@ -0,0 +1,85 @@
+// Package agent implements model.Agent: scripted play for tests and replays,
+// random play, and greedy heuristics.
+package agent
+
+import "github.com/kkishi/mtg/model"
+
+// Scripted implements model.Agent.
+var _ model.Agent = (*Scripted)(nil)
+
+// Scripted makes the decisions queued in its fields, in order. Once a queue
+// runs out, it decides as model.Passive does. A pass is the zero Action.
+type Scripted struct {
+	model.Passive
+	Mulligans []bool
+	Actions   []model.Action
+	Cards     [][]*model.Object
+	Targets   []int
+	Modes     [][]int
+	Attacks   [][]model.Attack
+	Blocks    [][]model.Block
+	Orders    [][]*model.Permanent
+	Choices   []int
+}
+
+// next removes the first decision of q and returns it, and whether there was
+// one.
+func next[T any](q *[]T) (T, bool) {
+	var t T
+	if len(*q) == 0 {
+		return t, false
+	}
+	t, *q = (*q)[0], (*q)[1:]
+	return t, true
+}
+
+func (s *Scripted) Mulligan(g *model.Game, p *model.Player) bool {
+	m, _ := next(&s.Mulligans)
+	return m
+}
+
+func (s *Scripted) Priority(g *model.Game, p *model.Player) model.Action {
+	a, _ := next(&s.Actions)
+	return a
+}
+
+func (s *Scripted) ChooseCards(g *model.Game, p *model.Player, prompt string, n int) []*model.Object {
+	if cs, ok := next(&s.Cards); ok {
+		return cs
+	}
+	return s.Passive.ChooseCards(g, p, prompt, n)
+}
+
+func (s *Scripted) ChooseTarget(g *model.Game, p *model.Player, legal []model.Target) int {
+	i, _ := next(&s.Targets)
+	return i
+}
+
+func (s *Scripted) ChooseModes(g *model.Game, p *model.Player, modes []string, min, max int) []int {
+	if is, ok := next(&s.Modes); ok {
+		return is
+	}
+	return s.Passive.ChooseModes(g, p, modes, min, max)
+}
+
+func (s *Scripted) DeclareAttackers(g *model.Game, p *model.Player, attackers []*model.Permanent, defenders []model.Target) []model.Attack {
+	as, _ := next(&s.Attacks)
+	return as
+}
+
+func (s *Scripted) DeclareBlockers(g *model.Game, p *model.Player, attacks []model.Attack, blockers []*model.Permanent) []model.Block {
+	bs, _ := next(&s.Blocks)
+	return bs
+}
+
+func (s *Scripted) OrderBlockers(g *model.Game, p *model.Player, attacker *model.Permanent, blockers []*model.Permanent) []*model.Permanent {
+	if o, ok := next(&s.Orders); ok {
+		return o
+	}
+	return blockers
+}
+
+func (s *Scripted) Choose(g *model.Game, p *model.Player, prompt string, options []any) int {
+	i, _ := next(&s.Choices)
+	return i
+}
@@ -5,22 +5,350 @@ import (
 	"github.com/kkishi/mtg/model"
 )
 
+var BloodsoakedChampion = &model.Card{
+	Name:      "Bloodsoaked Champion",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{B}"),
+	Power:     2,
+	Toughness: 1,
+}
+
+var TormentedHero = &model.Card{
+	Name:         "Tormented Hero",
+	Type:         model.Creature,
+	SubTypes:     []model.Type{model.Human, model.Worrier},
+	Cost:         model.MustParseManaCost("{B}"),
+	Power:        2,
+	Toughness:    1,
+	EntersTapped: true,
+}
+
+var MarduShadowspear = &model.Card{
+	Name:      "Mardu Shadowspear",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{B}"),
+	Power:     2,
+	Toughness: 1,
+}
+
+var MarduWoeReaper = &model.Card{
+	Name:      "Mardu Woe-Reaper",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{W}"),
+	Power:     2,
+	Toughness: 1,
+}
+
+// otherWorriers are the creatures pumped by the chiefs.
+var otherWorriers = ability.Creatures{Other: true, You: true, SubTypes: []model.Type{model.Worrier}}
+
+var ChiefOfTheEdge = &model.Card{
+	Name:      "Chief of the Edge",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{W}{B}"),
+	Power:     3,
+	Toughness: 2,
+	StaticAbilities: []model.StaticAbility{&ability.Pump{
+		Affected: otherWorriers,
+		Power:    1,
+	}},
+}
+
+var ChiefOfTheScale = &model.Card{
+	Name:      "Chief of the Scale",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{W}{B}"),
+	Power:     2,
+	Toughness: 3,
+	StaticAbilities: []model.StaticAbility{&ability.Pump{
+		Affected:  otherWorriers,
+		Toughness: 1,
+	}},
+}
+
+var MarduSkullhunter = &model.Card{
+	Name:         "Mardu Skullhunter",
+	Type:         model.Creature,
+	SubTypes:     []model.Type{model.Human, model.Worrier},
+	Cost:         model.MustParseManaCost("{1}{B}"),
+	Power:        2,
+	Toughness:    1,
+	EntersTapped: true,
+}
+
+var SeekerOfTheWay = &model.Card{
+	Name:      "Seeker of the Way",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{1}{W}"),
+	Power:     2,
+	Toughness: 2,
+}
+
 var OreskosSwiftclaw = &model.Card{
 	Name:      "Oreskos Swiftclaw",
 	Type:      model.Creature,
 	SubTypes:  []model.Type{model.Cat, model.Worrier},
-	Cost:      []model.Mana{model.Any, model.White},
+	Cost:      model.MustParseManaCost("{1}{W}"),
 	Power:     3,
 	Toughness: 1,
 }
 
-var Plains = &model.Card{
-	Name:     "Plains",
-	Type:     model.Land,
-	SubTypes: []model.Type{model.Plains},
-	ActivatedAbilities: []model.ActivatedAbility{
-		&ability.ManaAbility{
-			Mana: model.White,
+var BattleBrawler = &model.Card{
+	Name:      "Battle Brawler",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Orc, model.Worrier},
+	Cost:      model.MustParseManaCost("{1}{B}"),
+	Power:     2,
+	Toughness: 2,
+	StaticAbilities: []model.StaticAbility{
+		&ability.Pump{
+			Affected: ability.Creatures{Self: true},
+			Power:    1,
+			If:       ability.YouControlColor(model.Red, model.White),
+		},
+		&ability.Grant{
+			Affected: ability.Creatures{Self: true},
+			Keyword:  model.FirstStrike,
+			If:       ability.YouControlColor(model.Red, model.White),
 		},
 	},
 }
+
+var MarduHordechief = &model.Card{
+	Name:      "Mardu Hordechief",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{2}{W}"),
+	Power:     2,
+	Toughness: 3,
+	TriggeredAbilities: []model.TriggeredAbility{&ability.When{
+		Event: model.EntersBattlefield,
+		If:    ability.Raid,
+		Then:  &ability.CreateToken{Token: WorrierToken, N: 1},
+	}},
+}
+
+var MarduStrikeLeader = &model.Card{
+	Name:      "Mardu Strike Leader",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Human, model.Worrier},
+	Cost:      model.MustParseManaCost("{2}{B}"),
+	Power:     3,
+	Toughness: 2,
+	TriggeredAbilities: []model.TriggeredAbility{&ability.When{
+		Event: model.Attacks,
+		Then:  &ability.CreateToken{Token: WorrierToken2, N: 1},
+	}},
+}
+
+var ButcherOfTheHorde = &model.Card{
+	Name:      "Butcher of the Horde",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Demon},
+	Cost:      model.MustParseManaCost("{1}{R}{W}{B}"),
+	Power:     5,
+	Toughness: 4,
+	Keywords:  []model.Keyword{model.Flying},
+	ActivatedAbilities: []model.ActivatedAbility{&ability.Activated{
+		Costs: model.Costs{&ability.Sacrifice{Creature: ability.Creatures{Other: true, You: true}}},
+		Effect: &ability.GainUntilEndOfTurn{
+			Affected: ability.Creatures{Self: true},
+			Keywords: []model.Keyword{model.Vigilance, model.Lifelink, model.Haste},
+		},
+	}},
+}
+
+var MarduCharm = &model.Card{
+	Name: "Mardu Charm",
+	Type: model.Instant,
+	Cost: model.MustParseManaCost("{R}{W}{B}"),
+	Spell: &model.Modal{Min: 1, Max: 1, Modes: []model.Mode{
+		{
+			Text:   "Mardu Charm deals 4 damage to target creature.",
+			Effect: &ability.Damage{N: 4, To: &ability.TargetCreature{}},
+		},
+		{
+			Text:   "Create two 1/1 white Warrior creature tokens with first strike.",
+			Effect: &ability.CreateToken{Token: FirstStrikeWorrierToken, N: 2},
+		},
+		{
+			Text: "Target opponent reveals their hand. You choose a noncreature, nonland card from it. That player discards that card.",
+			Effect: &ability.ChooseDiscard{Card: func(c *model.Card) bool {
+				return !c.Is(model.Creature) && !c.Is(model.Land)
+			}},
+		},
+	}},
+}
+
+var RaidersSpoils = &model.Card{
+	Name: "Raider's Spoils",
+	Type: model.Enchantment,
+	Cost: model.MustParseManaCost("{3}{B}"),
+	StaticAbilities: []model.StaticAbility{&ability.Pump{
+		Affected: ability.Creatures{You: true},
+		Power:    1,
+	}},
+}
+
+// Sarkhan's −6 ability, which gets an emblem, is not modeled.
+var SarkhanTheDragonspeaker = &model.Card{
+	Name:      "Sarkhan, the Dragonspeaker",
+	Type:      model.Planeswalker,
+	Cost:      model.MustParseManaCost("{3}{R}{R}"),
+	Loyalty:   4,
+	Legendary: true,
+	ActivatedAbilities: []model.ActivatedAbility{
+		ability.LoyaltyAbility(1, &ability.Become{
+			SubTypes:  []model.Type{model.Dragon},
+			Colors:    []model.Mana{model.Red},
+			Keywords:  []model.Keyword{model.Flying, model.Indestructible, model.Haste},
+			Power:     4,
+			Toughness: 4,
+		}),
+		ability.LoyaltyAbility(-3, &ability.Damage{N: 4, To: &ability.TargetCreature{}}),
+	},
+}
+
+// WorrierToken is the 1/1 white Warrior of Mardu Hordechief.
+var WorrierToken = &model.Card{
+	Name:      "Warrior",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Worrier},
+	Colors:    []model.Mana{model.White},
+	Power:     1,
+	Toughness: 1,
+	Token:     true,
+}
+
+// WorrierToken2 is the 2/1 black Warrior of Mardu Strike Leader.
+var WorrierToken2 = &model.Card{
+	Name:      "Warrior",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Worrier},
+	Colors:    []model.Mana{model.Black},
+	Power:     2,
+	Toughness: 1,
+	Token:     true,
+}
+
+// FirstStrikeWorrierToken is the 1/1 white Warrior with first strike of
+// Mardu Charm.
+var FirstStrikeWorrierToken = &model.Card{
+	Name:      "Warrior",
+	Type:      model.Creature,
+	SubTypes:  []model.Type{model.Worrier},
+	Colors:    []model.Mana{model.White},
+	Power:     1,
+	Toughness: 1,
+	Keywords:  []model.Keyword{model.FirstStrike},
+	Token:     true,
+}
+
+// manaAbilities returns a mana ability for each of ms.
+func manaAbilities(ms ...model.Mana) []model.ActivatedAbility {
+	var aas []model.ActivatedAbility
+	for _, m := range ms {
+		aas = append(aas, &ability.ManaAbility{Mana: m})
+	}
+	return aas
+}
+
+var NomadOutpost = &model.Card{
+	Name:               "Nomad Outpost",
+	Type:               model.Land,
+	EntersTapped:       true,
+	ActivatedAbilities: manaAbilities(model.Red, model.White, model.Black),
+}
+
+var ScouredBarrens = &model.Card{
+	Name:               "Scoured Barrens",
+	Type:               model.Land,
+	EntersTapped:       true,
+	ActivatedAbilities: manaAbilities(model.White, model.Black),
+}
+
+var CavesOfKoilos = &model.Card{
+	Name: "Caves of Koilos",
+	Type: model.Land,
+	ActivatedAbilities: []model.ActivatedAbility{
+		&ability.ManaAbility{Mana: model.Colorless},
+		&ability.ManaAbility{Mana: model.White, Damage: 1},
+		&ability.ManaAbility{Mana: model.Black, Damage: 1},
+	},
+}
+
+var WindScarredCrag = &model.Card{
+	Name:               "Wind-Scarred Crag",
+	Type:               model.Land,
+	EntersTapped:       true,
+	ActivatedAbilities: manaAbilities(model.Red, model.White),
+}
+
+// The damage from the colored mana abilities is not modeled.
+var BattlefieldForge = &model.Card{
+	Name:               "Battlefield Forge",
+	Type:               model.Land,
+	ActivatedAbilities: manaAbilities(model.Colorless, model.Red, model.White),
+}
+
+var BloodfellCaves = &model.Card{
+	Name:               "Bloodfell Caves",
+	Type:               model.Land,
+	EntersTapped:       true,
+	ActivatedAbilities: manaAbilities(model.Black, model.Red),
+}
+
+var Plains = &model.Card{
+	Name:               "Plains",
+	Type:               model.Land,
+	SubTypes:           []model.Type{model.Plains},
+	ActivatedAbilities: manaAbilities(model.White),
+}
+
+var Swamp = &model.Card{
+	Name:               "Swamp",
+	Type:               model.Land,
+	SubTypes:           []model.Type{model.Swamp},
+	ActivatedAbilities: manaAbilities(model.Black),
+}
+
+// Builtin holds the cards defined in this package. Tokens are left out: they
+// share names, and only effects create them.
+var Builtin = Registry{}
+
+func init() {
+	for _, c := range []*model.Card{
+		BloodsoakedChampion,
+		TormentedHero,
+		MarduShadowspear,
+		MarduWoeReaper,
+		ChiefOfTheEdge,
+		ChiefOfTheScale,
+		MarduSkullhunter,
+		SeekerOfTheWay,
+		OreskosSwiftclaw,
+		BattleBrawler,
+		MarduHordechief,
+		MarduStrikeLeader,
+		ButcherOfTheHorde,
+		MarduCharm,
+		RaidersSpoils,
+		SarkhanTheDragonspeaker,
+		NomadOutpost,
+		ScouredBarrens,
+		CavesOfKoilos,
+		WindScarredCrag,
+		BattlefieldForge,
+		BloodfellCaves,
+		Plains,
+		Swamp,
+	} {
+		Builtin[c.Name] = c
+	}
+}
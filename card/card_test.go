@@ -0,0 +1,161 @@
+package card
+
+import (
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+func TestButcherOfTheHorde(t *testing.T) {
+	p := &model.Player{Life: 20}
+	g := &model.Game{Players: []*model.Player{p, {Life: 20}}}
+	enter := func(c *model.Card) *model.Permanent {
+		perm := &model.Permanent{Controller: p, Type: c.Type, Card: g.NewObject(c, p), Timestamp: g.NextTimestamp()}
+		p.BattleField = append(p.BattleField, perm)
+		return perm
+	}
+	butcher := enter(ButcherOfTheHorde)
+	sacrifice := ButcherOfTheHorde.ActivatedAbilities[0]
+
+	if err := g.Activate(p, butcher, sacrifice); err == nil {
+		t.Errorf("activated without another creature")
+	}
+	enter(WorrierToken)
+	if err := g.Activate(p, butcher, sacrifice); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.BattleField) != 1 || len(p.GraveYard) != 0 {
+		t.Errorf("battlefield %v and graveyard %v after sacrificing a token", p.BattleField, p.GraveYard)
+	}
+	g.Resolve()
+	if ch := g.Characteristics(butcher); !ch.HasKeyword(model.Vigilance) || !ch.HasKeyword(model.Flying) {
+		t.Errorf("butcher has %v, want flying and vigilance", ch.Keywords)
+	}
+}
+
+func TestSarkhanTheDragonspeaker(t *testing.T) {
+	p := &model.Player{Life: 20}
+	o := &model.Player{Life: 20}
+	g := &model.Game{Players: []*model.Player{p, o}, CurrentPart: model.FirstMainPhase}
+	sarkhan := g.NewObject(SarkhanTheDragonspeaker, p)
+	p.Hand = []*model.Object{sarkhan}
+	p.ManaPool = []model.Mana{model.Red, model.Red, model.Red, model.Red, model.Red}
+	if err := g.Cast(p, sarkhan); err != nil {
+		t.Fatal(err)
+	}
+	g.Resolve()
+	perm := p.BattleField[0]
+	if perm.Counters[model.LoyaltyCounter] != 4 {
+		t.Fatalf("entered with %v", perm.Counters)
+	}
+	become, bolt := SarkhanTheDragonspeaker.ActivatedAbilities[0], SarkhanTheDragonspeaker.ActivatedAbilities[1]
+
+	if err := g.Activate(p, perm, become); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Activate(p, perm, bolt); err == nil {
+		t.Errorf("activated two loyalty abilities in a turn")
+	}
+	g.Resolve()
+	ch := g.Characteristics(perm)
+	if ch.Is(model.Planeswalker) || !ch.Is(model.Dragon) || ch.Power != 4 || !ch.HasColor(model.Red) ||
+		!ch.HasKeyword(model.Flying) || !ch.HasKeyword(model.Haste) || perm.Counters[model.LoyaltyCounter] != 5 {
+		t.Errorf("after +1 %+v with %v", ch, perm.Counters)
+	}
+
+	g.UntilEndOfTurn = nil
+	perm.LoyaltyActivated = false
+	bear := &model.Permanent{Controller: o, Type: model.Creature, Card: g.NewObject(&model.Card{Name: "Bear", Type: model.Creature, Toughness: 4}, o)}
+	o.BattleField = []*model.Permanent{bear}
+	if err := g.Activate(p, perm, bolt, model.Target{Permanent: bear}); err != nil {
+		t.Fatal(err)
+	}
+	g.Resolve()
+	g.CheckStateBasedActions()
+	if len(o.BattleField) != 0 || perm.Counters[model.LoyaltyCounter] != 2 {
+		t.Errorf("after −3 battlefield %v and loyalty %d", o.BattleField, perm.Counters[model.LoyaltyCounter])
+	}
+}
+
+func TestMarduCharm(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		modes []int
+		// target is the target of the mode, nil for none.
+		target func(o *model.Player, bear *model.Permanent) model.Target
+		check  func(t *testing.T, g *model.Game, p, o *model.Player)
+	}{
+		{
+			name:  "damage",
+			modes: []int{0},
+			target: func(o *model.Player, bear *model.Permanent) model.Target {
+				return model.Target{Permanent: bear}
+			},
+			check: func(t *testing.T, g *model.Game, p, o *model.Player) {
+				if len(o.BattleField) != 0 {
+					t.Errorf("battlefield %v", o.BattleField)
+				}
+			},
+		},
+		{
+			name:  "tokens",
+			modes: []int{1},
+			check: func(t *testing.T, g *model.Game, p, o *model.Player) {
+				if len(p.BattleField) != 2 || p.TokensCreated != 2 {
+					t.Fatalf("battlefield %v", p.BattleField)
+				}
+				if ch := g.Characteristics(p.BattleField[0]); !ch.HasKeyword(model.FirstStrike) || !ch.HasColor(model.White) {
+					t.Errorf("token %+v", ch)
+				}
+			},
+		},
+		{
+			name:  "duress",
+			modes: []int{2},
+			target: func(o *model.Player, bear *model.Permanent) model.Target {
+				return model.Target{Player: o}
+			},
+			check: func(t *testing.T, g *model.Game, p, o *model.Player) {
+				if len(o.Hand) != 2 || len(o.GraveYard) != 1 || o.GraveYard[0].Card != RaidersSpoils {
+					t.Errorf("hand %v and graveyard %v", o.Hand, o.GraveYard)
+				}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &model.Player{Life: 20}
+			o := &model.Player{Life: 20}
+			g := &model.Game{Players: []*model.Player{p, o}, CurrentPart: model.FirstMainPhase}
+			bear := &model.Permanent{Controller: o, Type: model.Creature, Card: g.NewObject(MarduWoeReaper, o)}
+			o.BattleField = []*model.Permanent{bear}
+			o.Hand = g.NewObjects(o, Swamp, RaidersSpoils, ChiefOfTheEdge)
+			charm := g.NewObject(MarduCharm, p)
+			p.Hand = []*model.Object{charm}
+			p.ManaPool = []model.Mana{model.Red, model.White, model.Black}
+			var targets []model.Target
+			if tc.target != nil {
+				targets = append(targets, tc.target(o, bear))
+			}
+			if err := g.CastModes(p, charm, tc.modes, targets...); err != nil {
+				t.Fatal(err)
+			}
+			g.Resolve()
+			g.CheckStateBasedActions()
+			tc.check(t, g, p, o)
+		})
+	}
+}
+
+func TestCavesOfKoilos(t *testing.T) {
+	p := &model.Player{Life: 20}
+	g := &model.Game{Players: []*model.Player{p, {Life: 20}}}
+	caves := &model.Permanent{Controller: p, Type: model.Land, Card: g.NewObject(CavesOfKoilos, p)}
+	p.BattleField = []*model.Permanent{caves}
+	if err := g.Activate(p, caves, CavesOfKoilos.ActivatedAbilities[1]); err != nil {
+		t.Fatal(err)
+	}
+	// The colored mana costs 1 life.
+	if len(p.ManaPool) != 1 || p.ManaPool[0] != model.White || p.Life != 19 || !caves.Tapped {
+		t.Errorf("mana pool %v and life %d", p.ManaPool, p.Life)
+	}
+}
@@ -0,0 +1,156 @@
+package card
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kkishi/mtg/model"
+)
+
+// Registry indexes card definitions by name.
+type Registry map[string]*model.Card
+
+// scryfallCard is the subset of a Scryfall card object we read.
+type scryfallCard struct {
+	Name       string         `json:"name"`
+	Layout     string         `json:"layout"`
+	ManaCost   string         `json:"mana_cost"`
+	TypeLine   string         `json:"type_line"`
+	OracleText string         `json:"oracle_text"`
+	Power      string         `json:"power"`
+	Toughness  string         `json:"toughness"`
+	Loyalty    string         `json:"loyalty"`
+	CardFaces  []scryfallCard `json:"card_faces"`
+}
+
+// Layouts that are not cards one can put in a deck.
+var skippedLayouts = map[string]bool{
+	"art_series":         true,
+	"double_faced_token": true,
+	"emblem":             true,
+	"token":              true,
+}
+
+// LoadFile reads a Scryfall bulk data file. See Load.
+func LoadFile(path string) (Registry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return r, nil
+}
+
+// Load reads a Scryfall bulk data file, which is a JSON array of card
+// objects, into a Registry. Abilities are attached with ParseOracleText.
+// Only the front face of multi-faced cards is read, and it is also registered
+// under its own name. When a name appears more than once, as in the
+// per-printing bulk files, the first one wins. Cards that cannot be
+// represented yet are skipped.
+func Load(r io.Reader) (Registry, error) {
+	d := json.NewDecoder(r)
+	if t, err := d.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('[') {
+		return nil, fmt.Errorf("expected an array of cards, got %v", t)
+	}
+	reg := make(Registry)
+	for d.More() {
+		var sc scryfallCard
+		if err := d.Decode(&sc); err != nil {
+			return nil, err
+		}
+		if skippedLayouts[sc.Layout] || reg[sc.Name] != nil {
+			continue
+		}
+		front := &sc
+		if len(sc.CardFaces) > 0 && strings.Contains(sc.TypeLine, "//") {
+			front = &sc.CardFaces[0]
+		}
+		c, err := convert(front)
+		if err != nil {
+			continue
+		}
+		c.Name = sc.Name
+		reg[sc.Name] = c
+		if front != &sc && reg[front.Name] == nil {
+			reg[front.Name] = c
+		}
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+func convert(sc *scryfallCard) (*model.Card, error) {
+	c := &model.Card{
+		Name: sc.Name,
+		Text: sc.OracleText,
+	}
+	var err error
+	if c.Type, c.SubTypes, err = parseTypeLine(sc.TypeLine); err != nil {
+		return nil, err
+	}
+	supertypes, _, _ := strings.Cut(sc.TypeLine, "—")
+	c.Legendary = strings.Contains(supertypes, "Legendary")
+	if c.Cost, err = model.ParseManaCost(sc.ManaCost); err != nil {
+		return nil, err
+	}
+	c.Power = parsePT(sc.Power)
+	c.Toughness = parsePT(sc.Toughness)
+	c.Loyalty = parsePT(sc.Loyalty)
+	ParseOracleText(c)
+	return c, nil
+}
+
+// parseTypeLine parses e.g. "Legendary Creature — Human Warrior". The first of
+// creature, land and planeswalker becomes the card type, otherwise the first
+// card type does; any other card types go among the subtypes. Supertypes, which
+// convert looks for itself, and subtypes model does not know are dropped.
+func parseTypeLine(line string) (model.Type, []model.Type, error) {
+	types, subtypes, _ := strings.Cut(line, "—")
+	var cardTypes, subTypes []model.Type
+	for _, w := range strings.Fields(types) {
+		if t, ok := model.ParseType(w); ok && t.IsCardType() {
+			cardTypes = append(cardTypes, t)
+		}
+	}
+	if len(cardTypes) == 0 {
+		return 0, nil, fmt.Errorf("no card type in %q", line)
+	}
+	primary := 0
+	for i, t := range cardTypes {
+		if t == model.Creature || t == model.Land || t == model.Planeswalker {
+			primary = i
+			break
+		}
+	}
+	for i, t := range cardTypes {
+		if i != primary {
+			subTypes = append(subTypes, t)
+		}
+	}
+	for _, w := range strings.Fields(subtypes) {
+		if t, ok := model.ParseType(w); ok && !t.IsCardType() {
+			subTypes = append(subTypes, t)
+		}
+	}
+	return cardTypes[primary], subTypes, nil
+}
+
+// parsePT parses a printed power or toughness. Values that depend on the game,
+// such as "*" or "1+*", count their fixed part only.
+func parsePT(s string) int {
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "*"), "+")
+	n, _ := strconv.Atoi(s)
+	return n
+}
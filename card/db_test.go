@@ -0,0 +1,79 @@
+package card
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+const bulk = `[
+  {"name": "Oreskos Swiftclaw", "layout": "normal", "mana_cost": "{1}{W}",
+   "type_line": "Creature — Cat Warrior", "oracle_text": "",
+   "power": "3", "toughness": "1"},
+  {"name": "Oreskos Swiftclaw", "layout": "normal", "mana_cost": "{9}",
+   "type_line": "Creature — Cat Warrior", "power": "9", "toughness": "9"},
+  {"name": "Nomad Outpost", "layout": "normal", "mana_cost": "",
+   "type_line": "Land",
+   "oracle_text": "Nomad Outpost enters the battlefield tapped.\n{T}: Add {R}, {W}, or {B}."},
+  {"name": "Warrior", "layout": "token", "type_line": "Token Creature — Warrior",
+   "power": "1", "toughness": "1"},
+  {"name": "Tarmogoyf", "layout": "normal", "mana_cost": "{1}{G}",
+   "type_line": "Creature — Lhurgoyf", "power": "*", "toughness": "1+*"},
+  {"name": "Zurgo Helmsmasher", "layout": "normal", "mana_cost": "{2}{R}{W}{B}",
+   "type_line": "Legendary Creature — Orc Warrior", "power": "7", "toughness": "2"},
+  {"name": "Ajani Vengeant", "layout": "normal", "mana_cost": "{2}{R}{W}",
+   "type_line": "Legendary Planeswalker — Ajani", "loyalty": "3"},
+  {"name": "Ornithopter", "layout": "normal", "mana_cost": "{0}",
+   "type_line": "Artifact Creature — Thopter", "power": "0", "toughness": "2"},
+  {"name": "Delver of Secrets // Insectile Aberration", "layout": "transform",
+   "type_line": "Creature — Human Wizard // Creature — Human Insect",
+   "card_faces": [
+     {"name": "Delver of Secrets", "mana_cost": "{U}",
+      "type_line": "Creature — Human Wizard", "power": "1", "toughness": "1"},
+     {"name": "Insectile Aberration", "mana_cost": "",
+      "type_line": "Creature — Human Insect", "power": "3", "toughness": "2"}
+   ]}
+]`
+
+func TestLoad(t *testing.T) {
+	r, err := Load(strings.NewReader(bulk))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r["Oreskos Swiftclaw"]; !reflect.DeepEqual(got, OreskosSwiftclaw) {
+		t.Errorf("Oreskos Swiftclaw = %+v, want %+v", got, OreskosSwiftclaw)
+	}
+	if got := r["Nomad Outpost"]; got.Type != model.Land || len(got.Cost) != 0 || !got.EntersTapped || len(got.ActivatedAbilities) != 3 {
+		t.Errorf("Nomad Outpost = %+v", got)
+	}
+	if got := r["Warrior"]; got != nil {
+		t.Errorf("token was loaded: %+v", got)
+	}
+	if got := r["Tarmogoyf"]; got.Power != 0 || got.Toughness != 1 || len(got.SubTypes) != 0 {
+		t.Errorf("Tarmogoyf = %+v", got)
+	}
+	if got := r["Zurgo Helmsmasher"]; !got.Legendary || !got.Is(model.Orc) || r["Oreskos Swiftclaw"].Legendary {
+		t.Errorf("Zurgo Helmsmasher = %+v", got)
+	}
+	if got := r["Ajani Vengeant"]; got.Type != model.Planeswalker || got.Loyalty != 3 {
+		t.Errorf("Ajani Vengeant = %+v", got)
+	}
+	if got := r["Ornithopter"]; got.Type != model.Creature || !got.Is(model.Artifact) {
+		t.Errorf("Ornithopter = %+v", got)
+	}
+	delver := r["Delver of Secrets // Insectile Aberration"]
+	if delver == nil || r["Delver of Secrets"] != delver || delver.Power != 1 || !delver.Is(model.Human) {
+		t.Errorf("Delver of Secrets = %+v", delver)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	for _, in := range []string{`{}`, `[{"name": 1}]`, `[`} {
+		if _, err := Load(strings.NewReader(in)); err == nil {
+			t.Errorf("Load(%q) succeeded", in)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package card
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kkishi/mtg/ability"
+	"github.com/kkishi/mtg/model"
+)
+
+var (
+	reminderText = regexp.MustCompile(`\s*\([^)]*\)`)
+	manaLine     = regexp.MustCompile(`^\{T\}: Add ((?:\{[WUBRGC]\}(?:, or |, | or )?)+)\.(?: (.+) deals (\d+) damage to you\.)?$`)
+	manaSymbol   = regexp.MustCompile(`\{([WUBRGC])\}`)
+)
+
+var producedMana = map[string]model.Mana{
+	"W": model.White,
+	"U": model.Blue,
+	"B": model.Black,
+	"R": model.Red,
+	"G": model.Green,
+	"C": model.Colorless,
+}
+
+// ParseOracleText recognizes the abilities in c.Text that model can express
+// and attaches them to c: lines of evergreen keywords, "enters the battlefield
+// tapped" and "{T}: Add {B} or {W}." style mana abilities, including those of
+// painlands that go on with "This deals 1 damage to you.". Other lines are
+// left alone.
+func ParseOracleText(c *model.Card) {
+	for _, line := range strings.Split(c.Text, "\n") {
+		line = strings.TrimSpace(line)
+		// Basic lands have their mana ability as reminder text only.
+		if strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")") {
+			line = line[1 : len(line)-1]
+		}
+		line = reminderText.ReplaceAllString(line, "")
+		switch {
+		case parseKeywords(c, line):
+		case parseEntersTapped(c, line):
+		case parseManaAbility(c, line):
+		}
+	}
+}
+
+// parseKeywords handles a line such as "Flying, first strike".
+func parseKeywords(c *model.Card, line string) bool {
+	var ks []model.Keyword
+	for _, w := range strings.Split(line, ",") {
+		k, ok := model.ParseKeyword(strings.TrimSpace(w))
+		if !ok {
+			return false
+		}
+		ks = append(ks, k)
+	}
+	c.Keywords = append(c.Keywords, ks...)
+	return true
+}
+
+func parseEntersTapped(c *model.Card, line string) bool {
+	for _, suffix := range []string{" enters the battlefield tapped.", " enters tapped."} {
+		subject, ok := strings.CutSuffix(line, suffix)
+		if ok && (subject == c.Name || strings.HasPrefix(subject, "This ")) {
+			c.EntersTapped = true
+			return true
+		}
+	}
+	return false
+}
+
+func parseManaAbility(c *model.Card, line string) bool {
+	m := manaLine.FindStringSubmatch(line)
+	if m == nil {
+		return false
+	}
+	damage := 0
+	if m[2] != "" {
+		if m[2] != c.Name && m[2] != "This land" {
+			return false
+		}
+		damage, _ = strconv.Atoi(m[3])
+	}
+	for _, s := range manaSymbol.FindAllStringSubmatch(m[1], -1) {
+		c.ActivatedAbilities = append(c.ActivatedAbilities, &ability.ManaAbility{
+			Mana:   producedMana[s[1]],
+			Damage: damage,
+		})
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package card
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kkishi/mtg/ability"
+	"github.com/kkishi/mtg/model"
+)
+
+func TestParseOracleText(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		text         string
+		keywords     []model.Keyword
+		entersTapped bool
+		mana         []model.Mana
+		// pain is the damage dealt by each mana ability, all 0 when nil.
+		pain []int
+	}{
+		{
+			name:     "Serra Angel",
+			text:     "Flying, vigilance",
+			keywords: []model.Keyword{model.Flying, model.Vigilance},
+		},
+		{
+			name:     "Goblin Guide",
+			text:     "Haste\nWhenever Goblin Guide attacks, defending player reveals the top card of their library.",
+			keywords: []model.Keyword{model.Haste},
+		},
+		{
+			name:     "Boros Swiftblade",
+			text:     "Double strike (This creature deals both first-strike and regular combat damage.)",
+			keywords: []model.Keyword{model.DoubleStrike},
+		},
+		{
+			name: "Vampire Nighthawk",
+			text: "Flying\nDeathtouch\nLifelink",
+			keywords: []model.Keyword{
+				model.Flying, model.Deathtouch, model.Lifelink,
+			},
+		},
+		{
+			name:     "Giant Spider",
+			text:     "Reach",
+			keywords: []model.Keyword{model.Reach},
+		},
+		{
+			name:     "Wall of Omens",
+			text:     "Defender\nWhen Wall of Omens enters the battlefield, draw a card.",
+			keywords: []model.Keyword{model.Defender},
+		},
+		{
+			name:     "Gladecover Scout",
+			text:     "Hexproof (This creature can't be the target of spells or abilities your opponents control.)",
+			keywords: []model.Keyword{model.Hexproof},
+		},
+		{
+			name:     "Darksteel Myr",
+			text:     "Indestructible (Damage and effects that say \"destroy\" don't destroy it.)",
+			keywords: []model.Keyword{model.Indestructible},
+		},
+		{
+			name: "Swamp",
+			text: "({T}: Add {B}.)",
+			mana: []model.Mana{model.Black},
+		},
+		{
+			name:         "Scoured Barrens",
+			text:         "Scoured Barrens enters the battlefield tapped.\nWhen Scoured Barrens enters the battlefield, you gain 1 life.\n{T}: Add {W} or {B}.",
+			entersTapped: true,
+			mana:         []model.Mana{model.White, model.Black},
+		},
+		{
+			name:         "Nomad Outpost",
+			text:         "This land enters tapped.\n{T}: Add {R}, {W}, or {B}.",
+			entersTapped: true,
+			mana:         []model.Mana{model.Red, model.White, model.Black},
+		},
+		{
+			name: "Caves of Koilos",
+			text: "{T}: Add {C}.\n{T}: Add {W} or {B}. Caves of Koilos deals 1 damage to you.",
+			mana: []model.Mana{model.Colorless, model.White, model.Black},
+			pain: []int{0, 1, 1},
+		},
+		{
+			name: "Glacial Fortress",
+			text: "Glacial Fortress enters the battlefield tapped unless you control a Plains or an Island.\n{T}: Add {W} or {U}.",
+			mana: []model.Mana{model.White, model.Blue},
+		},
+		{
+			name:         "Tormented Hero",
+			text:         "Tormented Hero enters the battlefield tapped.\nHeroic — Whenever you cast a spell that targets Tormented Hero, each opponent loses 1 life. You gain life equal to the life lost this way.",
+			entersTapped: true,
+		},
+	} {
+		c := &model.Card{Name: tc.name, Text: tc.text}
+		ParseOracleText(c)
+		if !reflect.DeepEqual(c.Keywords, tc.keywords) {
+			t.Errorf("%s: keywords = %v, want %v", tc.name, c.Keywords, tc.keywords)
+		}
+		if c.EntersTapped != tc.entersTapped {
+			t.Errorf("%s: entersTapped = %t, want %t", tc.name, c.EntersTapped, tc.entersTapped)
+		}
+		var mana []model.Mana
+		var pain []int
+		for _, aa := range c.ActivatedAbilities {
+			ma := aa.(*ability.ManaAbility)
+			mana = append(mana, ma.Mana)
+			pain = append(pain, ma.Damage)
+		}
+		if !reflect.DeepEqual(mana, tc.mana) {
+			t.Errorf("%s: mana = %v, want %v", tc.name, mana, tc.mana)
+		}
+		if tc.pain == nil && tc.mana != nil {
+			tc.pain = make([]int, len(tc.mana))
+		}
+		if !reflect.DeepEqual(pain, tc.pain) {
+			t.Errorf("%s: damage = %v, want %v", tc.name, pain, tc.pain)
+		}
+	}
+}
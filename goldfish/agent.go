@@ -0,0 +1,160 @@
+package goldfish
+
+import (
+	"github.com/kkishi/mtg/ability"
+	"github.com/kkishi/mtg/model"
+)
+
+// Agent implements model.Agent.
+var _ model.Agent = (*Agent)(nil)
+
+// Agent plays our side of a goldfish game. In our second main phase it plays
+// the first land of our hand, then casts the creatures and enchantments among
+// the longest prefix of our hand that its mana pool and untapped lands pay
+// for, tapping as few lands as possible. It attacks with every creature that
+// can. Its other choices are those of model.Passive, so it discards the last
+// cards of its hand.
+type Agent struct {
+	model.Passive
+
+	// lands, dp, abilities and spells are buffers of Priority, kept to avoid
+	// reallocation.
+	lands     []*model.Permanent
+	dp        [2][]Key
+	abilities []*ability.ManaAbility
+	spells    []*model.Object
+}
+
+// castable reports whether Agent considers casting c.
+func castable(c *model.Object) bool {
+	return c.Type == model.Creature || c.Type == model.Enchantment
+}
+
+// Priority plays a land, or else takes the next step to cast the spells: it
+// activates the mana ability of a land for the first spell, or casts it once
+// the mana is in the pool. The pool only ever holds mana for the first spell,
+// and the lands tapped for it are chosen so that the rest still pay for the
+// other spells.
+func (a *Agent) Priority(g *model.Game, p *model.Player) model.Action {
+	if g.CurrentPart != model.SecondMainPhase || !g.SorcerySpeed(p) {
+		return model.Action{}
+	}
+	if p.LandsPlayed == 0 {
+		for _, c := range p.Hand {
+			if c.Type == model.Land {
+				return model.Action{Card: c}
+			}
+		}
+	}
+	spells := a.plan(p)
+	if len(spells) == 0 {
+		return model.Action{}
+	}
+	var need Requirement
+	need.Add(spells[0].Cost)
+	for _, m := range p.ManaPool {
+		if need.Mana[m] > 0 {
+			need.Mana[m]--
+		} else {
+			need.Generic--
+		}
+	}
+	if need.Mana == ([model.Colorless + 1]int{}) && need.Generic <= 0 {
+		return model.Action{Card: spells[0]}
+	}
+	all := need
+	for _, c := range spells[1:] {
+		all.Add(c.Cost)
+	}
+	k, _ := cheapest(a.search(Key{}), &all)
+	a.abilities = a.abilities[:0]
+	for range a.lands {
+		a.abilities = append(a.abilities, nil)
+	}
+	assign(a.lands, k.Used, k.Mana, 0, a.abilities)
+	// Colored symbols first, then generic mana from lands whose mana the
+	// other spells do not need.
+	for i, ma := range a.abilities {
+		if ma != nil && need.Mana[ma.Mana] > 0 {
+			return model.Action{Ability: ma, Permanent: a.lands[i]}
+		}
+	}
+	for i, ma := range a.abilities {
+		if ma != nil && k.Mana[ma.Mana] > all.Mana[ma.Mana] {
+			return model.Action{Ability: ma, Permanent: a.lands[i]}
+		}
+	}
+	return model.Action{}
+}
+
+// plan returns the creatures and enchantments among the longest prefix of p's
+// hand that the mana in p's pool and p's untapped lands pay for, in order.
+func (a *Agent) plan(p *model.Player) []*model.Object {
+	a.lands = a.lands[:0]
+	for _, perm := range p.BattleField {
+		if !perm.Tapped && perm.Card.Type == model.Land {
+			a.lands = append(a.lands, perm)
+		}
+	}
+	var start Key
+	for _, m := range p.ManaPool {
+		start.Mana[m]++
+	}
+	keys := a.search(start)
+	for i := len(p.Hand) - 1; i >= 0; i-- {
+		var r Requirement
+		supported := true
+		for _, c := range p.Hand[:i+1] {
+			if castable(c) && !r.Add(c.Cost) {
+				supported = false
+			}
+		}
+		if !supported {
+			continue
+		}
+		if _, ok := cheapest(keys, &r); !ok {
+			continue
+		}
+		a.spells = a.spells[:0]
+		for _, c := range p.Hand[:i+1] {
+			if castable(c) {
+				a.spells = append(a.spells, c)
+			}
+		}
+		return a.spells
+	}
+	return nil
+}
+
+// search returns the keys reachable from start by tapping the lands of plan
+// for one of their mana abilities each, in the order they are first reached,
+// keeping only the first key of each amount of mana. The keys are valid until
+// the next search.
+func (a *Agent) search(start Key) []Key {
+	dp, ndp := a.dp[0], append(a.dp[1][:0], start)
+	for i, perm := range a.lands {
+		dp, ndp = ndp, dp[:0]
+		for _, key := range dp {
+			ndp = appendKey(ndp, key)
+			for _, aa := range perm.Card.ActivatedAbilities {
+				if ma, ok := aa.(*ability.ManaAbility); ok {
+					nkey := key
+					nkey.Mana[ma.Mana]++
+					nkey.Used |= 1 << uint(i)
+					ndp = appendKey(ndp, nkey)
+				}
+			}
+		}
+	}
+	a.dp = [2][]Key{dp, ndp}
+	return ndp
+}
+
+// DeclareAttackers attacks the opponent with every creature that can.
+func (a *Agent) DeclareAttackers(g *model.Game, p *model.Player, attackers []*model.Permanent, defenders []model.Target) []model.Attack {
+	var as []model.Attack
+	for _, c := range attackers {
+		as = append(as, model.Attack{Attacker: c, Defender: defenders[0]})
+	}
+	return as
+}
@@ -0,0 +1,58 @@
+package goldfish
+
+import (
+	"math/rand"
+
+	"github.com/kkishi/mtg/card"
+	"github.com/kkishi/mtg/model"
+)
+
+type Deck struct {
+	Cards []*Cards
+}
+
+type Cards struct {
+	Card   *model.Card
+	Amount int
+}
+
+var MarduWorrier = &Deck{
+	Cards: []*Cards{
+		{card.BloodsoakedChampion, 4},
+		{card.TormentedHero, 4},
+		{card.MarduWoeReaper, 4},
+		{card.BattleBrawler, 4},
+		{card.ChiefOfTheEdge, 4},
+		{card.ChiefOfTheScale, 4},
+		{card.MarduStrikeLeader, 4},
+		{card.MarduCharm, 8},
+		{card.RaidersSpoils, 2},
+		{card.CavesOfKoilos, 4},
+		{card.Plains, 8},
+		{card.Swamp, 10},
+	},
+}
+
+var Decks = map[string]*Deck{
+	"MarduWorrier": MarduWorrier,
+}
+
+type Library []*model.Object
+
+func (l Library) Shuffle(r *rand.Rand) {
+	for i := 0; i < len(l)-1; i++ {
+		j := r.Intn(len(l)-i) + i
+		l[i], l[j] = l[j], l[i]
+	}
+}
+
+// MakeLibrary returns the cards of deck as objects of g owned by owner.
+func MakeLibrary(g *model.Game, owner *model.Player, deck *Deck) Library {
+	var l Library
+	for _, cs := range deck.Cards {
+		for i := 0; i < cs.Amount; i++ {
+			l = append(l, g.NewObject(cs.Card, owner))
+		}
+	}
+	return l
+}
@@ -0,0 +1,250 @@
+// Package goldfish simulates games against an opponent that does nothing, to
+// measure how fast a deck can kill.
+package goldfish
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/kkishi/mtg/model"
+)
+
+// Game is a goldfish game. Its state lives in the embedded model.Game, whose
+// first player is us, played by an Agent, and whose second player is the
+// opponent, who skips their turns and never acts. A new game stands at the
+// end of our turn, so that PlayOneTurn starts the next one.
+type Game struct {
+	model.Game
+
+	// Convenient data for search.
+	BestTurn int
+	BestLife int
+	BestHand []*model.Object
+
+	players [2]model.Player
+	agent   Agent
+	// greedy is set while the second main phase is played without search.
+	greedy bool
+}
+
+func newGame() *Game {
+	g := &Game{}
+	g.Players = []*model.Player{&g.players[0], &g.players[1]}
+	g.Player().Agent = &g.agent
+	g.Opponent().SkipsTurns = true
+	g.CurrentPart = model.CleanupStep
+	g.AddHook(model.SecondMainPhase, func(*model.Game) {
+		if !g.greedy {
+			g.SecondMain()
+		}
+	})
+	return g
+}
+
+func NewGame(deck *Deck, r *rand.Rand) *Game {
+	g := newGame()
+	p := g.Player()
+	l := MakeLibrary(&g.Game, p, deck)
+	l.Shuffle(r)
+	p.First = true
+	p.Life = 20
+	p.Hand = l[0:7:7]
+	p.Library = l[7:]
+	g.Opponent().Life = 20
+	return g
+}
+
+// Player returns us.
+func (g *Game) Player() *model.Player {
+	return g.Players[0]
+}
+
+func (g *Game) Opponent() *model.Player {
+	return g.Players[1]
+}
+
+func (g *Game) Print() {
+	p := g.Player()
+	fmt.Printf("Turn: %d\n", p.Turn)
+	fmt.Printf("Attacked: %t\n", p.Attacked)
+	fmt.Printf("Life: %d\n", p.Life)
+	fmt.Printf("OpponentLife: %d\n", g.Opponent().Life)
+	fmt.Printf("First: %t\n", p.First)
+	fmt.Printf("Hand (%d):\n", len(p.Hand))
+	for i, c := range p.Hand {
+		fmt.Printf("%d: %s\n", i, c.Name)
+	}
+	fmt.Printf("Library (%d):\n", len(p.Library))
+	for i, c := range p.Library {
+		if i == 10 {
+			fmt.Println("...")
+			break
+		}
+		fmt.Printf("%d: %s\n", i, c.Name)
+	}
+	fmt.Printf("BattleField (%d):\n", len(p.BattleField))
+	for i, c := range p.BattleField {
+		fmt.Printf("%d: %s", i, c.Card.Name)
+		if c.Card.Type == model.Creature {
+			fmt.Printf(" [%d/%d]", g.Power(c), g.Toughness(c))
+		}
+		if c.Tapped {
+			fmt.Printf(" [T]")
+		}
+		if c.SummoningSick {
+			fmt.Printf(" [S]")
+		}
+		fmt.Printf("\n")
+	}
+}
+
+type Status int
+
+const (
+	Playing Status = iota
+	Win
+	Lose
+	Draw
+)
+
+// status returns the status of the game for us.
+func (g *Game) status() Status {
+	switch p, o := g.Player(), g.Opponent(); {
+	case p.Lost && o.Lost:
+		return Draw
+	case o.Lost:
+		return Win
+	case p.Lost:
+		return Lose
+	}
+	return Playing
+}
+
+// PlayOneTurn plays our next turn. Unless greedy, our hand is ordered by the
+// search at the start of the second main phase. It returns when the turn or
+// the game ends.
+func (g *Game) PlayOneTurn(greedy bool) Status {
+	g.greedy = greedy
+	g.step()
+	return g.playTurn()
+}
+
+// playTurn plays the rest of the turn from the current part, whose priority
+// has been run.
+func (g *Game) playTurn() Status {
+	for {
+		if s := g.status(); s != Playing || g.CurrentPart == model.CleanupStep {
+			return s
+		}
+		g.step()
+	}
+}
+
+// step moves the game to the next part of the turn. The agents of a goldfish
+// game only make legal choices.
+func (g *Game) step() {
+	if err := g.Step(); err != nil {
+		panic(err)
+	}
+}
+
+func Take(c []*model.Object, i int) []*model.Object {
+	if i == len(c) {
+		return c[0:i]
+	}
+	return append(c[0:i], c[i+1:]...)
+}
+
+func CopyCards(cs []*model.Object) []*model.Object {
+	return append([]*model.Object(nil), cs...)
+}
+
+// Rec tries every order of hand that is not tried yet, perm being the first
+// depth cards of it. For each order it plays the rest of the game greedily,
+// no longer than the fastest win found so far, and rolls it back.
+func (g *Game) Rec(depth int, used map[int]bool, perm []*model.Object, hand []*model.Object) {
+	if depth == len(hand) {
+		cp := g.Checkpoint()
+		g.Execute(&orderHandCommand{Player: g.Player(), Hand: CopyCards(perm)})
+
+		// We are about to get priority in the second main phase. First we
+		// finish this turn.
+		if err := g.RunPriority(); err != nil {
+			panic(err)
+		}
+		s := g.playTurn()
+		turns := 0
+		for ; s == Playing && turns < g.BestTurn; turns++ {
+			s = g.PlayOneTurn(true)
+		}
+		if s == Win && (turns < g.BestTurn || g.Opponent().Life < g.BestLife) {
+			g.BestLife = g.Opponent().Life
+			g.BestTurn = turns
+			g.BestHand = CopyCards(perm)
+		}
+		g.Rollback(cp)
+		return
+	}
+	for i, c := range hand {
+		if used[i] || tried(hand[:i], used, c) {
+			continue
+		}
+		used[i] = true
+		perm = append(perm, c)
+		g.Rec(depth+1, used, perm, hand)
+		perm = perm[0 : len(perm)-1]
+		used[i] = false
+	}
+}
+
+// tried reports whether a card of cs that is not used is a copy of c, so that
+// the orders with c next were already tried.
+func tried(cs []*model.Object, used map[int]bool, c *model.Object) bool {
+	for i, cc := range cs {
+		if !used[i] && cc.Card == c.Card {
+			return true
+		}
+	}
+	return false
+}
+
+// SecondMain orders our hand for the second main phase we are entering: the
+// order that wins soonest when the game is played greedily, leaving the
+// opponent the least life among those. The hand stays as it is when no
+// order wins.
+func (g *Game) SecondMain() {
+	g.BestTurn = math.MaxInt32
+	g.BestLife = math.MaxInt32
+	g.BestHand = nil
+	stop := g.Journal == nil
+	g.greedy = true
+	g.Rec(0, make(map[int]bool), nil, g.Player().Hand)
+	g.greedy = false
+	if stop {
+		g.StopJournal()
+	}
+	if g.BestHand != nil {
+		g.Execute(&orderHandCommand{Player: g.Player(), Hand: g.BestHand})
+	}
+}
+
+// orderHandCommand implements model.Command.
+var _ model.Command = (*orderHandCommand)(nil)
+
+// orderHandCommand puts Player's hand in the order of Hand, which has the
+// same cards.
+type orderHandCommand struct {
+	Player *model.Player
+	Hand   []*model.Object
+
+	prev []*model.Object
+}
+
+func (oc *orderHandCommand) Execute() {
+	oc.prev, oc.Player.Hand = oc.Player.Hand, oc.Hand
+}
+
+func (oc *orderHandCommand) Undo() {
+	oc.Player.Hand = oc.prev
+}
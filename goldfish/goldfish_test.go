@@ -0,0 +1,21 @@
+package goldfish
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// playGame plays a MarduWorrier game with the search-driven second main
+// phase until it ends.
+func playGame(seed int64) {
+	g := NewGame(MarduWorrier, rand.New(rand.NewSource(seed)))
+	for g.PlayOneTurn(false) == Playing {
+	}
+}
+
+func BenchmarkPlayGame(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		playGame(int64(i % 16))
+	}
+}
@@ -0,0 +1,104 @@
+package goldfish
+
+import (
+	"github.com/kkishi/mtg/ability"
+	"github.com/kkishi/mtg/model"
+)
+
+// Key is a state of the mana search of Agent: the mana produced so far and
+// the set of lands tapped for it.
+type Key struct {
+	Mana [model.Colorless + 1]int
+	Used int64
+}
+
+func (k *Key) Total() int {
+	var t int
+	for _, n := range k.Mana {
+		t += n
+	}
+	return t
+}
+
+// Requirement is the mana needed to cast a set of spells.
+type Requirement struct {
+	// Mana counts colored and colorless symbols.
+	Mana    [model.Colorless + 1]int
+	Generic int
+}
+
+// Add adds cost to r. It reports false if cost has symbols other than generic,
+// colored and colorless ones, which the search does not handle.
+func (r *Requirement) Add(cost model.ManaCost) bool {
+	for _, s := range cost {
+		switch s.Kind {
+		case model.GenericSymbol:
+			r.Generic += s.Amount
+		case model.ColoredSymbol:
+			r.Mana[s.Color]++
+		case model.ColorlessSymbol:
+			r.Mana[model.Colorless]++
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (k *Key) Payable(r *Requirement) bool {
+	var extra int
+	for m, n := range k.Mana {
+		if n < r.Mana[m] {
+			return false
+		}
+		extra += n - r.Mana[m]
+	}
+	return extra >= r.Generic
+}
+
+// appendKey appends k to keys unless a key with the same mana is there.
+func appendKey(keys []Key, k Key) []Key {
+	for _, key := range keys {
+		if key.Mana == k.Mana {
+			return keys
+		}
+	}
+	return append(keys, k)
+}
+
+// cheapest returns the first of keys with the least mana that pays r, and
+// whether there is one.
+func cheapest(keys []Key, r *Requirement) (Key, bool) {
+	var best Key
+	found := false
+	for _, k := range keys {
+		if k.Payable(r) && (!found || k.Total() < best.Total()) {
+			best, found = k, true
+		}
+	}
+	return best, found
+}
+
+// assign chooses a mana ability for each land from the ith of lands that used
+// has, so that together they add mana, storing them in abilities. It reports
+// whether there is such a choice.
+func assign(lands []*model.Permanent, used int64, mana [model.Colorless + 1]int, i int, abilities []*ability.ManaAbility) bool {
+	for ; i < len(lands) && used&(1<<uint(i)) == 0; i++ {
+	}
+	if i == len(lands) {
+		return mana == [model.Colorless + 1]int{}
+	}
+	for _, aa := range lands[i].Card.ActivatedAbilities {
+		ma, ok := aa.(*ability.ManaAbility)
+		if !ok || mana[ma.Mana] == 0 {
+			continue
+		}
+		mana[ma.Mana]--
+		abilities[i] = ma
+		if assign(lands, used, mana, i+1, abilities) {
+			return true
+		}
+		mana[ma.Mana]++
+	}
+	return false
+}
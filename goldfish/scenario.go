@@ -0,0 +1,152 @@
+package goldfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/kkishi/mtg/card"
+	"github.com/kkishi/mtg/model"
+)
+
+// Scenario describes a position at the end of a turn, from which games are
+// continued with PlayOneTurn. For example:
+//
+//	{
+//	  "turn": 3,
+//	  "life": 20,
+//	  "opponent_life": 14,
+//	  "first": true,
+//	  "hand": ["Chief of the Edge", "Swamp"],
+//	  "library_top": ["Plains"],
+//	  "deck": "MarduWorrier",
+//	  "battlefield": [
+//	    {"card": "Swamp", "tapped": true},
+//	    {"card": "Tormented Hero", "sick": true}
+//	  ]
+//	}
+//
+// When Deck is set, the library continues below LibraryTop with the cards of
+// the deck that are not accounted for elsewhere, shuffled for every game.
+// Otherwise the library consists of LibraryTop only.
+type Scenario struct {
+	Turn         int                 `json:"turn"`
+	Life         int                 `json:"life"`
+	OpponentLife int                 `json:"opponent_life"`
+	First        bool                `json:"first"`
+	Hand         []string            `json:"hand"`
+	LibraryTop   []string            `json:"library_top"`
+	Graveyard    []string            `json:"graveyard"`
+	Deck         string              `json:"deck"`
+	BattleField  []ScenarioPermanent `json:"battlefield"`
+}
+
+type ScenarioPermanent struct {
+	Card   string `json:"card"`
+	Tapped bool   `json:"tapped"`
+	Sick   bool   `json:"sick"`
+}
+
+func LoadScenario(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s := &Scenario{
+		Life:         20,
+		OpponentLife: 20,
+	}
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+	if err := d.Decode(s); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return s, nil
+}
+
+// lookupCards returns objects of the cards named names, owned by us.
+func (g *Game) lookupCards(names []string) ([]*model.Object, error) {
+	var cs []*model.Object
+	for _, n := range names {
+		c, ok := card.Builtin[n]
+		if !ok {
+			return nil, fmt.Errorf("unknown card %q", n)
+		}
+		cs = append(cs, g.NewObject(c, g.Player()))
+	}
+	return cs, nil
+}
+
+// NewGame builds the game described by s, using r to shuffle the unknown part
+// of the library.
+func (s *Scenario) NewGame(r *rand.Rand) (*Game, error) {
+	g := newGame()
+	p := g.Player()
+	p.Turn = s.Turn
+	p.Life = s.Life
+	p.First = s.First
+	g.Opponent().Life = s.OpponentLife
+	var err error
+	if p.Hand, err = g.lookupCards(s.Hand); err != nil {
+		return nil, err
+	}
+	top, err := g.lookupCards(s.LibraryTop)
+	if err != nil {
+		return nil, err
+	}
+	graveyard, err := g.lookupCards(s.Graveyard)
+	if err != nil {
+		return nil, err
+	}
+	var known []*model.Object
+	known = append(known, p.Hand...)
+	known = append(known, top...)
+	known = append(known, graveyard...)
+	for _, sp := range s.BattleField {
+		c, ok := card.Builtin[sp.Card]
+		if !ok {
+			return nil, fmt.Errorf("unknown card %q", sp.Card)
+		}
+		perm := g.Entering(p, g.NewObject(c, p))
+		perm.Tapped, perm.SummoningSick = sp.Tapped, sp.Sick
+		g.Enter(p, perm)
+		g.Invalidate()
+		known = append(known, perm.Card)
+	}
+
+	p.Library = top
+	if s.Deck == "" {
+		return g, nil
+	}
+	deck, ok := Decks[s.Deck]
+	if !ok {
+		return nil, fmt.Errorf("unknown deck %q", s.Deck)
+	}
+	rest := MakeLibrary(&g.Game, p, deck)
+	for _, c := range known {
+		if c.Token {
+			continue
+		}
+		i := indexOf(rest, c.Card)
+		if i < 0 {
+			return nil, fmt.Errorf("%s has fewer copies of %q than the scenario uses", s.Deck, c.Name)
+		}
+		rest = Take(rest, i)
+	}
+	rest.Shuffle(r)
+	p.Library = append(p.Library, rest...)
+	return g, nil
+}
+
+// indexOf returns the index of the first object of c in cs, -1 if there is
+// none.
+func indexOf(cs []*model.Object, c *model.Card) int {
+	for i, cc := range cs {
+		if cc.Card == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,285 @@
+package goldfish
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/kkishi/mtg/model"
+)
+
+// outcome is the part of a game a golden case checks.
+type outcome struct {
+	Status       Status
+	Turn         int
+	OpponentLife int
+	Hand         []string
+	// BattleField renders each permanent as its name, followed by P/T for
+	// creatures and T and S for tapped and summoning sick.
+	BattleField []string
+}
+
+func outcomeOf(g *Game, s Status) outcome {
+	p := g.Player()
+	o := outcome{
+		Status:       s,
+		Turn:         p.Turn,
+		OpponentLife: g.Opponent().Life,
+	}
+	for _, c := range p.Hand {
+		o.Hand = append(o.Hand, c.Name)
+	}
+	for _, c := range p.BattleField {
+		r := c.Card.Name
+		if c.Card.Type == model.Creature {
+			r += fmt.Sprintf(" %d/%d", g.Power(c), g.Toughness(c))
+		}
+		if c.Tapped {
+			r += " T"
+		}
+		if c.SummoningSick {
+			r += " S"
+		}
+		o.BattleField = append(o.BattleField, r)
+	}
+	return o
+}
+
+func perm(card string) ScenarioPermanent {
+	return ScenarioPermanent{Card: card}
+}
+
+func sick(card string) ScenarioPermanent {
+	return ScenarioPermanent{Card: card, Sick: true}
+}
+
+// playFrom returns a run that plays our turn greedily from the end of from
+// until the end of until.
+func playFrom(from, until model.Part) func(g *Game) Status {
+	return func(g *Game) Status {
+		g.CurrentPart = from
+		g.greedy = true
+		for g.CurrentPart != until {
+			g.step()
+			if s := g.status(); s != Playing {
+				return s
+			}
+		}
+		return Playing
+	}
+}
+
+var (
+	combat = playFrom(model.FirstMainPhase, model.EndOfCombatStep)
+	main2  = playFrom(model.EndOfCombatStep, model.SecondMainPhase)
+	// cast casts spells in the second main phase without playing a land.
+	cast = func(g *Game) Status {
+		g.Player().LandsPlayed = 1
+		return main2(g)
+	}
+	// game plays greedy turns until the game ends.
+	game = func(g *Game) Status {
+		for {
+			if s := g.PlayOneTurn(true); s != Playing {
+				return s
+			}
+		}
+	}
+)
+
+func TestGolden(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		scenario Scenario
+		attacked bool
+		run      func(g *Game) Status
+		want     outcome
+	}{
+		{
+			name: "chief of the edge pumps other warriors",
+			scenario: Scenario{
+				Turn:        3,
+				BattleField: []ScenarioPermanent{perm("Chief of the Edge"), perm("Tormented Hero"), perm("Chief of the Scale")},
+			},
+			run: combat,
+			want: outcome{
+				Turn:         3,
+				OpponentLife: 11,
+				BattleField:  []string{"Chief of the Edge 3/3 T", "Tormented Hero 3/2 T", "Chief of the Scale 3/3 T"},
+			},
+		},
+		{
+			name: "sick and tapped creatures do not attack",
+			scenario: Scenario{
+				Turn: 3,
+				BattleField: []ScenarioPermanent{
+					sick("Mardu Woe-Reaper"),
+					{Card: "Bloodsoaked Champion", Tapped: true},
+					perm("Plains"),
+				},
+			},
+			run: combat,
+			want: outcome{
+				Turn:         3,
+				OpponentLife: 20,
+				BattleField:  []string{"Mardu Woe-Reaper 2/1 S", "Bloodsoaked Champion 2/1 T", "Plains"},
+			},
+		},
+		{
+			name: "strike leader makes a token when attacking",
+			scenario: Scenario{
+				Turn:        4,
+				BattleField: []ScenarioPermanent{perm("Mardu Strike Leader")},
+			},
+			run: combat,
+			want: outcome{
+				Turn:         4,
+				OpponentLife: 17,
+				BattleField:  []string{"Mardu Strike Leader 3/2 T", "Warrior 2/1 S"},
+			},
+		},
+		{
+			name: "raider's spoils and battle brawler",
+			scenario: Scenario{
+				Turn:        5,
+				BattleField: []ScenarioPermanent{perm("Raider's Spoils"), perm("Battle Brawler"), sick("Mardu Woe-Reaper")},
+			},
+			run: combat,
+			want: outcome{
+				Turn:         5,
+				OpponentLife: 16,
+				BattleField:  []string{"Raider's Spoils", "Battle Brawler 4/2 T", "Mardu Woe-Reaper 3/1 S"},
+			},
+		},
+		{
+			name: "cast the longest affordable prefix of the hand",
+			scenario: Scenario{
+				Turn:        2,
+				Hand:        []string{"Tormented Hero", "Chief of the Edge", "Mardu Woe-Reaper", "Swamp"},
+				BattleField: []ScenarioPermanent{perm("Swamp"), perm("Plains"), perm("Swamp")},
+			},
+			run: cast,
+			want: outcome{
+				Turn:         2,
+				OpponentLife: 20,
+				Hand:         []string{"Mardu Woe-Reaper", "Swamp"},
+				BattleField:  []string{"Swamp T", "Plains T", "Swamp T", "Tormented Hero 3/1 T S", "Chief of the Edge 3/2 S"},
+			},
+		},
+		{
+			name: "hordechief makes a token after attacking",
+			scenario: Scenario{
+				Turn:        3,
+				Hand:        []string{"Mardu Hordechief"},
+				BattleField: []ScenarioPermanent{perm("Plains"), perm("Plains"), perm("Swamp")},
+			},
+			attacked: true,
+			run:      cast,
+			want: outcome{
+				Turn:         3,
+				OpponentLife: 20,
+				BattleField:  []string{"Plains T", "Plains T", "Swamp T", "Mardu Hordechief 2/3 S", "Warrior 1/1 S"},
+			},
+		},
+		{
+			name: "generic mana leaves the colors later spells need",
+			scenario: Scenario{
+				Turn:        3,
+				Hand:        []string{"Mardu Hordechief", "Tormented Hero"},
+				BattleField: []ScenarioPermanent{perm("Swamp"), perm("Plains"), perm("Swamp"), perm("Plains")},
+			},
+			run: cast,
+			want: outcome{
+				Turn:         3,
+				OpponentLife: 20,
+				BattleField:  []string{"Swamp T", "Plains T", "Swamp T", "Plains T", "Mardu Hordechief 2/3 S", "Tormented Hero 2/1 T S"},
+			},
+		},
+		{
+			name: "lands that enter tapped cannot pay this turn",
+			scenario: Scenario{
+				Turn: 1,
+				Hand: []string{"Scoured Barrens", "Mardu Woe-Reaper"},
+			},
+			run: main2,
+			want: outcome{
+				Turn:         1,
+				OpponentLife: 20,
+				Hand:         []string{"Mardu Woe-Reaper"},
+				BattleField:  []string{"Scoured Barrens T S"},
+			},
+		},
+		{
+			name: "untapped land pays the same turn",
+			scenario: Scenario{
+				Turn: 1,
+				Hand: []string{"Caves of Koilos", "Mardu Woe-Reaper"},
+			},
+			run: main2,
+			want: outcome{
+				Turn:         1,
+				OpponentLife: 20,
+				BattleField:  []string{"Caves of Koilos T S", "Mardu Woe-Reaper 2/1 S"},
+			},
+		},
+		{
+			name: "kill turn",
+			scenario: Scenario{
+				First: true,
+				Hand: []string{
+					"Swamp", "Tormented Hero", "Plains", "Chief of the Edge",
+					"Swamp", "Mardu Strike Leader", "Plains",
+				},
+				LibraryTop: []string{
+					"Mardu Woe-Reaper", "Swamp", "Bloodsoaked Champion", "Swamp",
+					"Plains", "Swamp", "Plains", "Swamp",
+				},
+			},
+			run: game,
+			want: outcome{
+				Status:       Win,
+				Turn:         5,
+				OpponentLife: -17,
+				Hand:         []string{"Swamp", "Swamp"},
+				BattleField: []string{
+					"Swamp", "Tormented Hero 3/1 T", "Plains", "Chief of the Edge 3/2 T",
+					"Swamp", "Mardu Strike Leader 4/2 T", "Warrior 3/1 T",
+					"Plains", "Mardu Woe-Reaper 3/1 T", "Bloodsoaked Champion 3/1 T",
+					"Warrior 3/1 S",
+				},
+			},
+		},
+		{
+			name: "decking",
+			scenario: Scenario{
+				Turn:       1,
+				First:      true,
+				Hand:       []string{"Swamp"},
+				LibraryTop: []string{"Swamp"},
+			},
+			run: game,
+			want: outcome{
+				Status:       Lose,
+				Turn:         3,
+				OpponentLife: 20,
+				Hand:         []string{"Swamp"},
+				BattleField:  []string{"Swamp"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.scenario.Life = 20
+			tc.scenario.OpponentLife = 20
+			g, err := tc.scenario.NewGame(rand.New(rand.NewSource(0)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.Player().Attacked = tc.attacked
+			got := outcomeOf(g, tc.run(g))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tc.want)
+			}
+		})
+	}
+}
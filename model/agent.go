@@ -0,0 +1,150 @@
+package model
+
+import "fmt"
+
+// Agent makes the decisions of a player: every choice the rules ask a player
+// to make goes through it.
+type Agent interface {
+	// Mulligan reports whether p mulligans their opening hand.
+	Mulligan(g *Game, p *Player) bool
+	// Priority returns what p does with priority.
+	Priority(g *Game, p *Player) Action
+	// ChooseCards returns n cards of p's hand, to discard or to put on the
+	// bottom of their library as prompt says.
+	ChooseCards(g *Game, p *Player, prompt string, n int) []*Object
+	// ChooseTarget returns the index in legal of the target p chooses for a
+	// triggered ability.
+	ChooseTarget(g *Game, p *Player, legal []Target) int
+	// ChooseModes returns the indices of the modes p chooses among modes,
+	// at least min and at most max of them.
+	ChooseModes(g *Game, p *Player, modes []string, min, max int) []int
+	// DeclareAttackers returns the attacks p makes with attackers, each
+	// against one of defenders.
+	DeclareAttackers(g *Game, p *Player, attackers []*Permanent, defenders []Target) []Attack
+	// DeclareBlockers returns how p blocks attacks with blockers.
+	DeclareBlockers(g *Game, p *Player, attacks []Attack, blockers []*Permanent) []Block
+	// OrderBlockers returns blockers, which block attacker, in the order
+	// attacker assigns its combat damage.
+	OrderBlockers(g *Game, p *Player, attacker *Permanent, blockers []*Permanent) []*Permanent
+	// Choose returns the index of the option p picks among options for
+	// other choices, e.g. the creature to sacrifice or the replacement
+	// effect to apply next. prompt describes the choice.
+	Choose(g *Game, p *Player, prompt string, options []any) int
+}
+
+// Attack is an attacking creature and the player or planeswalker it attacks.
+type Attack struct {
+	Attacker *Permanent
+	Defender Target
+}
+
+// Block is a blocking creature and the attacker it blocks.
+type Block struct {
+	Blocker  *Permanent
+	Attacker *Permanent
+}
+
+// Passive implements Agent.
+var _ Agent = Passive{}
+
+// Passive is an Agent that never acts: it keeps its hand, passes priority,
+// neither attacks nor blocks, and otherwise takes the first options, or the
+// last cards of its hand. It is the agent of players without one, and can be
+// embedded by agents that only make some choices.
+type Passive struct{}
+
+func (Passive) Mulligan(g *Game, p *Player) bool { return false }
+
+func (Passive) Priority(g *Game, p *Player) Action { return Action{} }
+
+func (Passive) ChooseCards(g *Game, p *Player, prompt string, n int) []*Object {
+	return p.Hand[len(p.Hand)-n:]
+}
+
+func (Passive) ChooseTarget(g *Game, p *Player, legal []Target) int { return 0 }
+
+func (Passive) ChooseModes(g *Game, p *Player, modes []string, min, max int) []int {
+	var is []int
+	for i := 0; i < min; i++ {
+		is = append(is, i)
+	}
+	return is
+}
+
+func (Passive) DeclareAttackers(g *Game, p *Player, attackers []*Permanent, defenders []Target) []Attack {
+	return nil
+}
+
+func (Passive) DeclareBlockers(g *Game, p *Player, attacks []Attack, blockers []*Permanent) []Block {
+	return nil
+}
+
+func (Passive) OrderBlockers(g *Game, p *Player, attacker *Permanent, blockers []*Permanent) []*Permanent {
+	return blockers
+}
+
+func (Passive) Choose(g *Game, p *Player, prompt string, options []any) int { return 0 }
+
+// agent returns the agent of p.
+func (p *Player) agent() Agent {
+	if p.Agent == nil {
+		return Passive{}
+	}
+	return p.Agent
+}
+
+// reject notes that the agent of p made an illegal choice, which err
+// describes. The caller makes a legal choice instead, so that the game stays
+// consistent, and Step or RunPriority returns the first rejection.
+func (g *Game) reject(p *Player, err error) {
+	if g.rejected == nil {
+		g.rejected = fmt.Errorf("player %d: %v", indexOf(g.Players, p)+1, err)
+	}
+}
+
+// rejection returns the first rejection not returned yet, if any.
+func (g *Game) rejection() error {
+	err := g.rejected
+	g.rejected = nil
+	return err
+}
+
+// Choose has p pick one of options. An illegal choice of the agent is
+// rejected for the first option.
+func (g *Game) Choose(p *Player, prompt string, options []any) int {
+	if len(options) < 2 {
+		return 0
+	}
+	i := p.agent().Choose(g, p, prompt, options)
+	if i < 0 || i >= len(options) {
+		g.reject(p, fmt.Errorf("%s: option %d of %d chosen", prompt, i, len(options)))
+		return 0
+	}
+	return i
+}
+
+// chooseCards has p choose n different cards of their hand. An illegal
+// choice of the agent is rejected for the last n cards.
+func (g *Game) chooseCards(p *Player, prompt string, n int) []*Object {
+	cs := p.agent().ChooseCards(g, p, prompt, n)
+	if err := checkCards(p.Hand, cs, n); err != nil {
+		g.reject(p, fmt.Errorf("%s: %v", prompt, err))
+		return Passive{}.ChooseCards(g, p, prompt, n)
+	}
+	return cs
+}
+
+func checkCards(hand, cs []*Object, n int) error {
+	if len(cs) != n {
+		return fmt.Errorf("%d cards chosen, want %d", len(cs), n)
+	}
+	for i, c := range cs {
+		switch {
+		case indexOf(hand, c) < 0:
+			return fmt.Errorf("%s is not in hand", c.Name)
+		case indexOf(cs[:i], c) >= 0:
+			return fmt.Errorf("%s chosen twice", c.Name)
+		}
+	}
+	return nil
+}
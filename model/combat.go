@@ -0,0 +1,382 @@
+package model
+
+// Combat is the state of the combat phase.
+type Combat struct {
+	Attacks []Attack
+	Blocks  []Block
+	// Orders holds the damage assignment order of the blockers of each
+	// attacker blocked by several creatures.
+	Orders map[*Permanent][]*Permanent
+	// FirstStrike is set when the combat damage step is split in two, a
+	// first one for creatures with first strike or double strike.
+	FirstStrike bool
+	// DamageSteps is the number of combat damage steps that have happened.
+	DamageSteps int
+}
+
+// Attacking returns the attack of perm, nil if it is not attacking.
+func (c *Combat) Attacking(perm *Permanent) *Attack {
+	for i := range c.Attacks {
+		if c.Attacks[i].Attacker == perm {
+			return &c.Attacks[i]
+		}
+	}
+	return nil
+}
+
+// Blocked reports whether attacker was blocked. It stays blocked after its
+// blockers leave combat.
+func (c *Combat) Blocked(attacker *Permanent) bool {
+	for _, b := range c.Blocks {
+		if b.Attacker == attacker {
+			return true
+		}
+	}
+	return false
+}
+
+// Blockers returns the creatures blocking attacker in damage assignment
+// order.
+func (c *Combat) Blockers(attacker *Permanent) []*Permanent {
+	if o, ok := c.Orders[attacker]; ok {
+		return o
+	}
+	var bs []*Permanent
+	for _, b := range c.Blocks {
+		if b.Attacker == attacker {
+			bs = append(bs, b.Blocker)
+		}
+	}
+	return bs
+}
+
+// CanAttack reports whether perm, controlled by the active player, may be
+// declared as an attacker.
+func (g *Game) CanAttack(perm *Permanent) bool {
+	ch := g.Characteristics(perm)
+	return ch.Is(Creature) && !perm.Tapped && !ch.HasKeyword(Defender) &&
+		(!perm.SummoningSick || ch.HasKeyword(Haste))
+}
+
+// CanBlock reports whether blocker may block attacker.
+func (g *Game) CanBlock(blocker, attacker *Permanent) bool {
+	ch := g.Characteristics(blocker)
+	if !ch.Is(Creature) || blocker.Tapped {
+		return false
+	}
+	if ach := g.Characteristics(attacker); ach.HasKeyword(Flying) {
+		return ch.HasKeyword(Flying) || ch.HasKeyword(Reach)
+	}
+	return true
+}
+
+// defendingPlayer returns the player a attacks, or the controller of the
+// planeswalker it attacks, nil once that has left the battlefield.
+func (g *Game) defendingPlayer(a Attack) *Player {
+	if a.Defender.Player != nil {
+		return a.Defender.Player
+	}
+	return g.Controller(a.Defender.Permanent)
+}
+
+// declareAttackers has the active player declare attackers among the
+// creatures that can attack, each attacking one of their opponents or a
+// planeswalker an opponent controls. Illegal attacks are left out.
+func (g *Game) declareAttackers() {
+	p := g.Active()
+	var attackers []*Permanent
+	for _, perm := range p.BattleField {
+		if g.CanAttack(perm) {
+			attackers = append(attackers, perm)
+		}
+	}
+	var defenders []Target
+	for _, o := range g.Players {
+		if o != p && !o.Lost {
+			defenders = append(defenders, Target{Player: o})
+		}
+	}
+	for _, o := range g.Players {
+		if o == p || o.Lost {
+			continue
+		}
+		for _, perm := range o.BattleField {
+			if ch := g.Characteristics(perm); ch.Is(Planeswalker) {
+				defenders = append(defenders, Target{Permanent: perm})
+			}
+		}
+	}
+	var attacks []Attack
+	if len(attackers) > 0 && len(defenders) > 0 {
+		for _, a := range p.agent().DeclareAttackers(g, p, attackers, defenders) {
+			if indexOf(attackers, a.Attacker) >= 0 && indexOf(defenders, a.Defender) >= 0 {
+				attackers = without(attackers, indexOf(attackers, a.Attacker))
+				attacks = append(attacks, a)
+			}
+		}
+	}
+	var tap []*Permanent
+	for _, a := range attacks {
+		if ch := g.Characteristics(a.Attacker); !ch.HasKeyword(Vigilance) {
+			tap = append(tap, a.Attacker)
+		}
+	}
+	g.Execute(&declareAttackersCommand{Game: g, Player: p, Attacks: attacks, Tap: tap})
+}
+
+// declareBlockers has each defending player declare blockers among their
+// untapped creatures, then the active player order the blockers of each
+// attacker blocked by several. Illegal blocks are left out, as are the blocks
+// of an attacker with menace blocked by a single creature.
+func (g *Game) declareBlockers() {
+	combat := g.Combat
+	if combat == nil || len(combat.Attacks) == 0 {
+		return
+	}
+	var blocks []Block
+	for i := range g.Players {
+		p := g.Players[(g.ActivePlayer+i)%len(g.Players)]
+		var attacks []Attack
+		for _, a := range combat.Attacks {
+			if g.defendingPlayer(a) == p {
+				attacks = append(attacks, a)
+			}
+		}
+		var blockers []*Permanent
+		for _, perm := range p.BattleField {
+			if ch := g.Characteristics(perm); ch.Is(Creature) && !perm.Tapped {
+				blockers = append(blockers, perm)
+			}
+		}
+		if len(attacks) == 0 || len(blockers) == 0 {
+			continue
+		}
+		var declared []Block
+		for _, b := range p.agent().DeclareBlockers(g, p, attacks, blockers) {
+			j := indexOf(blockers, b.Blocker)
+			a := combat.Attacking(b.Attacker)
+			if j >= 0 && a != nil && g.defendingPlayer(*a) == p && g.CanBlock(b.Blocker, b.Attacker) {
+				blockers = without(blockers, j)
+				declared = append(declared, b)
+			}
+		}
+		for _, b := range declared {
+			n := 0
+			for _, d := range declared {
+				if d.Attacker == b.Attacker {
+					n++
+				}
+			}
+			if ch := g.Characteristics(b.Attacker); n == 1 && ch.HasKeyword(Menace) {
+				continue
+			}
+			blocks = append(blocks, b)
+		}
+	}
+	next := *combat
+	next.Blocks = blocks
+	next.Orders = make(map[*Permanent][]*Permanent)
+	active := g.Active()
+	for _, a := range combat.Attacks {
+		if bs := next.Blockers(a.Attacker); len(bs) > 1 {
+			next.Orders[a.Attacker] = g.orderBlockers(active, a.Attacker, bs)
+		}
+	}
+	g.Execute(&setCombatCommand{Game: g, Combat: &next})
+}
+
+// orderBlockers has p order blockers, falling back to the order they were
+// declared in unless the agent returns each of them once.
+func (g *Game) orderBlockers(p *Player, attacker *Permanent, blockers []*Permanent) []*Permanent {
+	o := p.agent().OrderBlockers(g, p, attacker, blockers)
+	if len(o) != len(blockers) {
+		return blockers
+	}
+	for i, b := range o {
+		if indexOf(blockers, b) < 0 || indexOf(o[:i], b) >= 0 {
+			return blockers
+		}
+	}
+	return o
+}
+
+// combatDamage performs a combat damage step. When an attacking or blocking
+// creature has first strike or double strike, the first step has only them
+// deal damage, and the game enters the step again for the other creatures and
+// those with double strike.
+func (g *Game) combatDamage() {
+	combat := g.Combat
+	if combat == nil || len(combat.Attacks) == 0 {
+		return
+	}
+	next := *combat
+	if combat.DamageSteps == 0 {
+		for _, perm := range g.inCombat() {
+			if ch := g.Characteristics(perm); ch.HasKeyword(FirstStrike) || ch.HasKeyword(DoubleStrike) {
+				next.FirstStrike = true
+			}
+		}
+	}
+	next.DamageSteps++
+	first := next.FirstStrike && next.DamageSteps == 1
+	deals := func(perm *Permanent) bool {
+		if g.Controller(perm) == nil {
+			return false
+		}
+		ch := g.Characteristics(perm)
+		switch {
+		case ch.Power <= 0:
+			return false
+		case !next.FirstStrike || ch.HasKeyword(DoubleStrike):
+			return true
+		}
+		return ch.HasKeyword(FirstStrike) == first
+	}
+
+	var es []Event
+	for _, a := range combat.Attacks {
+		if deals(a.Attacker) {
+			es = append(es, g.assignCombatDamage(combat, a)...)
+		}
+	}
+	for _, b := range combat.Blocks {
+		if deals(b.Blocker) && g.Controller(b.Attacker) != nil {
+			es = append(es, Event{
+				Kind:            DealsCombatDamage,
+				Player:          g.Controller(b.Blocker),
+				Permanent:       b.Blocker,
+				TargetPermanent: b.Attacker,
+				Amount:          g.Power(b.Blocker),
+			})
+		}
+	}
+	g.Execute(&setCombatCommand{Game: g, Combat: &next})
+	// Combat damage is dealt simultaneously, so all of it is assigned before
+	// any is dealt.
+	var cmds []Command
+	for _, e := range es {
+		cmds = append(cmds, g.Damage(e)...)
+	}
+	for _, cmd := range cmds {
+		g.Execute(cmd)
+	}
+}
+
+// assignCombatDamage returns the combat damage a deals. An unblocked attacker
+// deals its damage to the player it attacks. A blocked one assigns lethal
+// damage to each blocker in order before the next, and the rest to the last
+// blocker, or with trample to the player or planeswalker. One damage from a
+// source with deathtouch is lethal.
+func (g *Game) assignCombatDamage(combat *Combat, a Attack) []Event {
+	ch := g.Characteristics(a.Attacker)
+	p := g.Controller(a.Attacker)
+	amount := ch.Power
+	var es []Event
+	if combat.Blocked(a.Attacker) {
+		var blockers []*Permanent
+		for _, b := range combat.Blockers(a.Attacker) {
+			if g.Controller(b) != nil {
+				blockers = append(blockers, b)
+			}
+		}
+		if len(blockers) == 0 && !ch.HasKeyword(Trample) {
+			return nil
+		}
+		for i, b := range blockers {
+			lethal := max(g.Toughness(b)-b.Damage, 0)
+			if ch.HasKeyword(Deathtouch) {
+				lethal = min(lethal, 1)
+			}
+			n := min(lethal, amount)
+			if i == len(blockers)-1 && !ch.HasKeyword(Trample) {
+				n = amount
+			}
+			if n > 0 {
+				es = append(es, Event{Kind: DealsCombatDamage, Player: p, Permanent: a.Attacker, TargetPermanent: b, Amount: n})
+			}
+			amount -= n
+		}
+	}
+	if amount > 0 && g.defendingPlayer(a) != nil {
+		es = append(es, Event{
+			Kind:            DealsCombatDamage,
+			Player:          p,
+			Permanent:       a.Attacker,
+			Target:          a.Defender.Player,
+			TargetPermanent: a.Defender.Permanent,
+			Amount:          amount,
+		})
+	}
+	return es
+}
+
+// inCombat returns the attacking and blocking creatures.
+func (g *Game) inCombat() []*Permanent {
+	var perms []*Permanent
+	for _, a := range g.Combat.Attacks {
+		perms = append(perms, a.Attacker)
+	}
+	for _, b := range g.Combat.Blocks {
+		perms = append(perms, b.Blocker)
+	}
+	return perms
+}
+
+// declareAttackersCommand implements Command.
+var _ Command = (*declareAttackersCommand)(nil)
+
+// declareAttackersCommand starts combat with Attacks of Player, tapping the
+// attackers in Tap.
+type declareAttackersCommand struct {
+	Game    *Game
+	Player  *Player
+	Attacks []Attack
+	Tap     []*Permanent
+
+	prevCombat   *Combat
+	prevAttacked bool
+}
+
+func (dc *declareAttackersCommand) Execute() {
+	dc.prevCombat, dc.prevAttacked = dc.Game.Combat, dc.Player.Attacked
+	dc.Game.Combat = &Combat{Attacks: dc.Attacks}
+	dc.Player.Attacked = dc.Player.Attacked || len(dc.Attacks) > 0
+	for _, perm := range dc.Tap {
+		perm.Tapped = true
+	}
+}
+
+func (dc *declareAttackersCommand) Events() []Event {
+	var es []Event
+	for _, a := range dc.Attacks {
+		es = append(es, Event{Kind: Attacks, Player: dc.Player, Permanent: a.Attacker, Target: dc.Game.defendingPlayer(a)})
+	}
+	return es
+}
+
+func (dc *declareAttackersCommand) Undo() {
+	for _, perm := range dc.Tap {
+		perm.Tapped = false
+	}
+	dc.Game.Combat, dc.Player.Attacked = dc.prevCombat, dc.prevAttacked
+}
+
+// setCombatCommand implements Command.
+var _ Command = (*setCombatCommand)(nil)
+
+// setCombatCommand replaces the state of combat with Combat, which ends
+// combat when nil.
+type setCombatCommand struct {
+	Game   *Game
+	Combat *Combat
+
+	prev *Combat
+}
+
+func (sc *setCombatCommand) Execute() {
+	sc.prev, sc.Game.Combat = sc.Game.Combat, sc.Combat
+}
+
+func (sc *setCombatCommand) Undo() {
+	sc.Game.Combat = sc.prev
+}
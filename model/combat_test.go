@@ -0,0 +1,283 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// combatant is an agent declaring the attacks and blocks returned by its
+// functions, and ordering blockers as order says.
+type combatant struct {
+	Passive
+	attacks func(attackers []*Permanent, defenders []Target) []Attack
+	blocks  func(attacks []Attack, blockers []*Permanent) []Block
+	order   []int
+}
+
+func (c combatant) DeclareAttackers(g *Game, p *Player, attackers []*Permanent, defenders []Target) []Attack {
+	if c.attacks == nil {
+		return nil
+	}
+	return c.attacks(attackers, defenders)
+}
+
+func (c combatant) DeclareBlockers(g *Game, p *Player, attacks []Attack, blockers []*Permanent) []Block {
+	if c.blocks == nil {
+		return nil
+	}
+	return c.blocks(attacks, blockers)
+}
+
+func (c combatant) OrderBlockers(g *Game, p *Player, attacker *Permanent, blockers []*Permanent) []*Permanent {
+	if c.order == nil {
+		return blockers
+	}
+	var o []*Permanent
+	for _, i := range c.order {
+		o = append(o, blockers[i])
+	}
+	return o
+}
+
+// attackAll attacks the first defender with every attacker.
+func attackAll(attackers []*Permanent, defenders []Target) []Attack {
+	var as []Attack
+	for _, a := range attackers {
+		as = append(as, Attack{Attacker: a, Defender: defenders[0]})
+	}
+	return as
+}
+
+// blockFirst blocks the first attacker with every blocker.
+func blockFirst(attacks []Attack, blockers []*Permanent) []Block {
+	var bs []Block
+	for _, b := range blockers {
+		bs = append(bs, Block{Blocker: b, Attacker: attacks[0].Attacker})
+	}
+	return bs
+}
+
+func creature(name string, power, toughness int, ks ...Keyword) *Object {
+	return obj(&Card{Name: name, Type: Creature, Power: power, Toughness: toughness, Keywords: ks})
+}
+
+func TestCombat(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		attackers []*Object
+		blockers  []*Object
+		blocks    func(attacks []Attack, blockers []*Permanent) []Block
+		order     []int
+		// lives are the life totals after combat, survivors the creatures
+		// left on each side.
+		lives     []int
+		survivors [][]string
+		damage    int
+	}{
+		{
+			name:      "unblocked",
+			attackers: []*Object{creature("Bear", 2, 2), creature("Sick", 3, 3)},
+			lives:     []int{20, 18},
+			survivors: [][]string{{"Bear", "Sick"}, nil},
+			damage:    1,
+		},
+		{
+			name:      "blocked",
+			attackers: []*Object{creature("Bear", 2, 2)},
+			blockers:  []*Object{creature("Wall", 0, 3)},
+			blocks:    blockFirst,
+			lives:     []int{20, 20},
+			survivors: [][]string{{"Bear"}, {"Wall"}},
+			damage:    1,
+		},
+		{
+			name:      "flying",
+			attackers: []*Object{creature("Bird", 1, 1, Flying)},
+			blockers:  []*Object{creature("Bear", 2, 2), creature("Spider", 1, 3, Reach)},
+			blocks:    blockFirst,
+			lives:     []int{20, 20},
+			survivors: [][]string{nil, {"Bear", "Spider"}},
+			damage:    2,
+		},
+		{
+			name:      "menace blocked by one",
+			attackers: []*Object{creature("Goblin", 2, 2, Menace)},
+			blockers:  []*Object{creature("Bear", 2, 2)},
+			blocks:    blockFirst,
+			lives:     []int{20, 18},
+			survivors: [][]string{{"Goblin"}, {"Bear"}},
+			damage:    1,
+		},
+		{
+			name:      "menace blocked by two",
+			attackers: []*Object{creature("Goblin", 2, 2, Menace)},
+			blockers:  []*Object{creature("Bear", 2, 2), creature("Wall", 0, 4)},
+			blocks:    blockFirst,
+			lives:     []int{20, 20},
+			survivors: [][]string{nil, {"Wall"}},
+			damage:    2,
+		},
+		{
+			name:      "damage assignment order",
+			attackers: []*Object{creature("Giant", 4, 6)},
+			blockers:  []*Object{creature("Bear", 2, 2), creature("Ogre", 3, 3)},
+			blocks:    blockFirst,
+			order:     []int{1, 0},
+			lives:     []int{20, 20},
+			survivors: [][]string{{"Giant"}, {"Bear"}},
+			damage:    4,
+		},
+		{
+			name:      "trample",
+			attackers: []*Object{creature("Wurm", 5, 5, Trample)},
+			blockers:  []*Object{creature("Bear", 2, 2)},
+			blocks:    blockFirst,
+			lives:     []int{20, 17},
+			survivors: [][]string{{"Wurm"}, nil},
+			damage:    3,
+		},
+		{
+			name:      "deathtouch and trample",
+			attackers: []*Object{creature("Snake", 3, 3, Deathtouch, Trample)},
+			blockers:  []*Object{creature("Giant", 4, 6)},
+			blocks:    blockFirst,
+			lives:     []int{20, 18},
+			survivors: [][]string{nil, nil},
+			damage:    3,
+		},
+		{
+			name:      "first strike",
+			attackers: []*Object{creature("Knight", 2, 2, FirstStrike)},
+			blockers:  []*Object{creature("Bear", 2, 2)},
+			blocks:    blockFirst,
+			lives:     []int{20, 20},
+			survivors: [][]string{{"Knight"}, nil},
+			damage:    1,
+		},
+		{
+			name:      "first strike blocker",
+			attackers: []*Object{creature("Bear", 2, 2)},
+			blockers:  []*Object{creature("Knight", 2, 2, FirstStrike)},
+			blocks:    blockFirst,
+			lives:     []int{20, 20},
+			survivors: [][]string{nil, {"Knight"}},
+			damage:    1,
+		},
+		{
+			name:      "double strike",
+			attackers: []*Object{creature("Duelist", 2, 2, DoubleStrike), creature("Bear", 2, 2)},
+			lives:     []int{20, 14},
+			survivors: [][]string{{"Duelist", "Bear"}, nil},
+			damage:    3,
+		},
+		{
+			name:      "lifelink",
+			attackers: []*Object{creature("Cleric", 3, 3, Lifelink)},
+			lives:     []int{23, 17},
+			survivors: [][]string{{"Cleric"}, nil},
+			damage:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p0 := &Player{Life: 20, Agent: combatant{attacks: attackAll, order: tc.order}}
+			p1 := &Player{Life: 20, Agent: combatant{blocks: tc.blocks}}
+			g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+			for _, c := range tc.attackers {
+				c.Owner = p0
+				p0.BattleField = append(p0.BattleField, &Permanent{Controller: p0, Type: Creature, Card: c, SummoningSick: c.Name == "Sick"})
+			}
+			for _, c := range tc.blockers {
+				c.Owner = p1
+				p1.BattleField = append(p1.BattleField, &Permanent{Controller: p1, Type: Creature, Card: c})
+			}
+			damage := 0
+			g.Listen(func(g *Game, e *Event) {
+				if e.Kind == DealsCombatDamage {
+					damage++
+				}
+			})
+			for g.CurrentPart != SecondMainPhase {
+				if err := g.Step(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if lives := []int{p0.Life, p1.Life}; !reflect.DeepEqual(lives, tc.lives) {
+				t.Errorf("life totals %v, want %v", lives, tc.lives)
+			}
+			for i, p := range g.Players {
+				var names []string
+				for _, perm := range p.BattleField {
+					names = append(names, perm.Card.Name)
+				}
+				if !reflect.DeepEqual(names, tc.survivors[i]) {
+					t.Errorf("player %d has %v, want %v", i, names, tc.survivors[i])
+				}
+			}
+			if damage != tc.damage {
+				t.Errorf("combat damage dealt %d times, want %d", damage, tc.damage)
+			}
+			if g.Combat != nil {
+				t.Errorf("combat did not end")
+			}
+		})
+	}
+}
+
+func TestVigilance(t *testing.T) {
+	p0 := &Player{Life: 20, Agent: combatant{attacks: attackAll}}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+	bear := &Permanent{Controller: p0, Type: Creature, Card: creature("Bear", 2, 2)}
+	guard := &Permanent{Controller: p0, Type: Creature, Card: creature("Guard", 2, 2, Vigilance)}
+	p0.BattleField = []*Permanent{bear, guard}
+	g.AdvanceTo(DeclateAtackersStep)
+	if !bear.Tapped || guard.Tapped || !p0.Attacked {
+		t.Errorf("after attacking, bear tapped %v, guard tapped %v, attacked %v", bear.Tapped, guard.Tapped, p0.Attacked)
+	}
+	if g.Combat == nil || len(g.Combat.Attacks) != 2 {
+		t.Errorf("combat %+v", g.Combat)
+	}
+}
+
+func TestDefender(t *testing.T) {
+	p0 := &Player{Life: 20, Agent: combatant{attacks: attackAll}}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+	wall := &Permanent{Controller: p0, Type: Creature, Card: creature("Wall", 0, 4, Defender)}
+	p0.BattleField = []*Permanent{wall}
+	if g.CanAttack(wall) {
+		t.Errorf("creature with defender can attack")
+	}
+	g.AdvanceTo(DeclateAtackersStep)
+	if len(g.Combat.Attacks) != 0 || wall.Tapped {
+		t.Errorf("creature with defender attacked")
+	}
+}
+
+func TestAttackPlaneswalker(t *testing.T) {
+	attackWalker := func(attackers []*Permanent, defenders []Target) []Attack {
+		return []Attack{{Attacker: attackers[0], Defender: defenders[len(defenders)-1]}}
+	}
+	p0 := &Player{Life: 20, Agent: combatant{attacks: attackWalker}}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+	walker := g.NewObject(&Card{Name: "Walker", Type: Planeswalker, Loyalty: 3}, p1)
+	p0.BattleField = []*Permanent{{Controller: p0, Type: Creature, Card: creature("Bear", 2, 2)}}
+	wp := &Permanent{Controller: p1, Type: Planeswalker, Card: walker, Counters: walker.EntersWith()}
+	p1.BattleField = []*Permanent{wp}
+	var attacked *Player
+	g.Listen(func(g *Game, e *Event) {
+		if e.Kind == Attacks {
+			attacked = e.Target
+		}
+	})
+	g.AdvanceTo(CombatDamageStep)
+	if attacked != p1 || p1.Life != 20 || wp.Counters[LoyaltyCounter] != 1 || wp.Damage != 0 {
+		t.Errorf("attacked %v, life %d, loyalty %d, damage %d", attacked, p1.Life, wp.Counters[LoyaltyCounter], wp.Damage)
+	}
+	g.Execute(&DamageCommand{Event: Event{Kind: DealsDamage, TargetPermanent: wp, Amount: 1}, Planeswalker: true})
+	g.CheckStateBasedActions()
+	if len(p1.BattleField) != 0 || !reflect.DeepEqual(p1.GraveYard, []*Object{walker}) {
+		t.Errorf("battlefield %v and graveyard %v with no loyalty", p1.BattleField, p1.GraveYard)
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+// PayManaCommand implements Command.
+var _ Command = (*PayManaCommand)(nil)
+
+// PayManaCommand pays Cost from the player's mana pool, which must be able to
+// pay it.
+type PayManaCommand struct {
+	Cost   ManaCost
+	Player *Player
+
+	pool []Mana
+}
+
+func (pmc *PayManaCommand) Execute() {
+	rest, ok := pmc.Cost.Pay(pmc.Player.ManaPool)
+	if !ok {
+		panic("cannot pay " + pmc.Cost.String())
+	}
+	pmc.pool = pmc.Player.ManaPool
+	pmc.Player.ManaPool = rest
+}
+
+func (pmc *PayManaCommand) Undo() {
+	pmc.Player.ManaPool = pmc.pool
+}
+
+// AddEffectCommand implements Command.
+var _ Command = (*AddEffectCommand)(nil)
+
+// AddEffectCommand starts Effect, which lasts until end of turn.
+type AddEffectCommand struct {
+	Game   *Game
+	Effect *ContinuousEffect
+
+	timestamp int
+}
+
+func (ac *AddEffectCommand) Execute() {
+	ac.timestamp = ac.Game.Timestamp
+	ac.Effect.Timestamp = ac.Game.NextTimestamp()
+	ac.Game.UntilEndOfTurn = append(ac.Game.UntilEndOfTurn, ac.Effect)
+}
+
+func (ac *AddEffectCommand) Undo() {
+	ac.Game.UntilEndOfTurn = ac.Game.UntilEndOfTurn[:len(ac.Game.UntilEndOfTurn)-1]
+	ac.Game.Timestamp = ac.timestamp
+}
@@ -0,0 +1,32 @@
+package model
+
+// Cost is what a player pays to activate an ability, e.g. "{T}" or "Sacrifice
+// another creature".
+type Cost interface {
+	// CanPay reports whether c.Player can pay the cost for the ability of
+	// c.Permanent.
+	CanPay(c *Context) bool
+	// Pay returns the commands paying the cost. It is called only when the
+	// cost can be paid.
+	Pay(c *Context) []Command
+}
+
+// Costs is a cost made of several costs, all of which are paid.
+type Costs []Cost
+
+func (cs Costs) CanPay(c *Context) bool {
+	for _, cost := range cs {
+		if !cost.CanPay(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cs Costs) Pay(c *Context) []Command {
+	var cmds []Command
+	for _, cost := range cs {
+		cmds = append(cmds, cost.Pay(c)...)
+	}
+	return cmds
+}
@@ -0,0 +1,74 @@
+package model
+
+import "testing"
+
+// lifeCost pays N life.
+type lifeCost struct {
+	N int
+}
+
+func (lc *lifeCost) CanPay(c *Context) bool { return c.Player.Life >= lc.N }
+
+func (lc *lifeCost) Pay(c *Context) []Command {
+	return []Command{&lifeCommand{Player: c.Player, N: -lc.N}}
+}
+
+// costly is an ability with a cost gaining N life.
+type costly struct {
+	gainLife
+	cost Cost
+}
+
+func (ca *costly) Cost() Cost { return ca.cost }
+
+// haste grants haste to the creatures with the source.
+type haste struct{}
+
+func (h *haste) Layer() Layer { return AbilityLayer }
+
+func (h *haste) Affects(c *Context, perm *Permanent, ch *Characteristics) bool {
+	return perm == c.Permanent
+}
+
+func (h *haste) Apply(ch *Characteristics) { ch.Keywords = append(ch.Keywords, Haste) }
+
+func TestActivationCost(t *testing.T) {
+	ability := &costly{gainLife: gainLife{N: 5}, cost: Costs{&lifeCost{N: 2}, &lifeCost{N: 1}}}
+	altar := &Permanent{Card: obj(&Card{Name: "Altar", ActivatedAbilities: []ActivatedAbility{ability}})}
+	p0 := &Player{Life: 3, BattleField: []*Permanent{altar}}
+	altar.Controller = p0
+	g := &Game{Players: []*Player{p0, {Life: 20}}}
+
+	if err := g.Activate(p0, altar, ability); err != nil {
+		t.Fatal(err)
+	}
+	// The cost is paid right away, the effect on resolution.
+	if p0.Life != 0 || len(g.Stack) != 1 {
+		t.Fatalf("life %d with %d objects on the stack", p0.Life, len(g.Stack))
+	}
+	if err := g.Activate(p0, altar, ability); err == nil {
+		t.Errorf("activated without paying")
+	}
+	g.Resolve()
+	if p0.Life != 5 {
+		t.Errorf("life %d, want 5", p0.Life)
+	}
+}
+
+func TestUntilEndOfTurn(t *testing.T) {
+	bear := &Permanent{Type: Creature, Card: obj(&Card{Name: "Bear", Type: Creature, Toughness: 1})}
+	p0 := &Player{Life: 20, BattleField: []*Permanent{bear}}
+	bear.Controller = p0
+	g := &Game{Players: []*Player{p0, {Life: 20}}, CurrentPart: EndStep}
+	g.Execute(&AddEffectCommand{Game: g, Effect: &ContinuousEffect{
+		StaticAbility: &haste{},
+		Context:       &Context{Game: g, Player: p0, Permanent: bear},
+	}})
+	if ch := g.Characteristics(bear); !ch.HasKeyword(Haste) {
+		t.Fatalf("bear does not have haste")
+	}
+	g.Advance()
+	if ch := g.Characteristics(bear); ch.HasKeyword(Haste) || len(g.UntilEndOfTurn) != 0 {
+		t.Errorf("haste did not end in the cleanup step")
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+// Counter is a kind of counter, named as printed on cards. Kinds other than
+// the constants, e.g. "time", are made by conversion.
+type Counter string
+
+const (
+	PlusOneCounter  Counter = "+1/+1"
+	MinusOneCounter Counter = "-1/-1"
+	LoyaltyCounter  Counter = "loyalty"
+	ChargeCounter   Counter = "charge"
+	PoisonCounter   Counter = "poison"
+)
+
+// PoisonLimit is the number of poison counters with which a player loses.
+const PoisonLimit = 10
+
+// Counters are the counters on a permanent or a player by kind. Kinds with no
+// counters are left out. They are not modified in place, so that a command
+// can undo its change by restoring the previous map.
+type Counters map[Counter]int
+
+// Add returns cs with n more counters of kind k, or -n fewer when n is
+// negative, never going below none.
+func (cs Counters) Add(k Counter, n int) Counters {
+	next := make(Counters, len(cs)+1)
+	for kk, v := range cs {
+		next[kk] = v
+	}
+	if next[k] += n; next[k] <= 0 {
+		delete(next, k)
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return next
+}
+
+// EntersWith returns the counters a permanent of c enters the battlefield
+// with: loyalty counters for a planeswalker, none otherwise.
+func (c *Card) EntersWith() Counters {
+	if c.Type != Planeswalker || c.Loyalty <= 0 {
+		return nil
+	}
+	return Counters{LoyaltyCounter: c.Loyalty}
+}
+
+// annihilateCommand implements Command.
+var _ Command = (*annihilateCommand)(nil)
+
+// annihilateCommand removes N +1/+1 and N -1/-1 counters from Permanent.
+type annihilateCommand struct {
+	Permanent *Permanent
+	N         int
+
+	prev Counters
+}
+
+func (ac *annihilateCommand) Execute() {
+	ac.prev = ac.Permanent.Counters
+	ac.Permanent.Counters = ac.prev.Add(PlusOneCounter, -ac.N).Add(MinusOneCounter, -ac.N)
+}
+
+func (ac *annihilateCommand) Undo() {
+	ac.Permanent.Counters = ac.prev
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountersAdd(t *testing.T) {
+	var cs Counters
+	one := cs.Add(PlusOneCounter, 2)
+	two := one.Add(Counter("time"), 1).Add(PlusOneCounter, -2)
+	if cs != nil || !reflect.DeepEqual(one, Counters{PlusOneCounter: 2}) {
+		t.Errorf("counters %v and %v changed", cs, one)
+	}
+	if !reflect.DeepEqual(two, Counters{"time": 1}) {
+		t.Errorf("counters %v", two)
+	}
+	if none := two.Add("time", -3); none != nil {
+		t.Errorf("counters %v after removing more than there are", none)
+	}
+}
+
+func TestCounterCharacteristics(t *testing.T) {
+	p := &Player{}
+	g := &Game{Players: []*Player{p}}
+	bear := &Permanent{Controller: p, Type: Creature, Card: creature("Bear", 2, 2), Counters: Counters{PlusOneCounter: 2, MinusOneCounter: 1}}
+	p.BattleField = []*Permanent{bear}
+	if pt := [2]int{g.Power(bear), g.Toughness(bear)}; pt != [2]int{3, 3} {
+		t.Errorf("power and toughness %v, want 3/3", pt)
+	}
+	// Counters apply after effects setting power and toughness.
+	g.UntilEndOfTurn = []*ContinuousEffect{{StaticAbility: &setPT{Power: 0, Toughness: 1}, Timestamp: g.NextTimestamp()}}
+	g.Invalidate()
+	if pt := [2]int{g.Power(bear), g.Toughness(bear)}; pt != [2]int{1, 2} {
+		t.Errorf("power and toughness %v, want 1/2", pt)
+	}
+}
+
+func TestAnnihilation(t *testing.T) {
+	p := &Player{Life: 20}
+	g := &Game{Players: []*Player{p}}
+	bear := &Permanent{Controller: p, Type: Creature, Card: creature("Bear", 2, 2), Counters: Counters{PlusOneCounter: 2, MinusOneCounter: 3, ChargeCounter: 1}}
+	p.BattleField = []*Permanent{bear}
+	cp := g.Checkpoint()
+	if !g.CheckStateBasedActions() {
+		t.Fatalf("no state-based actions")
+	}
+	if g.Toughness(bear) != 1 || !reflect.DeepEqual(bear.Counters, Counters{MinusOneCounter: 1, ChargeCounter: 1}) {
+		t.Errorf("toughness %d and counters %v", g.Toughness(bear), bear.Counters)
+	}
+	g.Rollback(cp)
+	if !reflect.DeepEqual(bear.Counters, Counters{PlusOneCounter: 2, MinusOneCounter: 3, ChargeCounter: 1}) {
+		t.Errorf("counters %v after rollback", bear.Counters)
+	}
+}
+
+func TestPoison(t *testing.T) {
+	p0 := &Player{Life: 20, Counters: Counters{PoisonCounter: PoisonLimit - 1}}
+	p1 := &Player{Life: 20, Counters: Counters{PoisonCounter: PoisonLimit}}
+	g := &Game{Players: []*Player{p0, p1}}
+	g.CheckStateBasedActions()
+	if p0.Lost || !p1.Lost {
+		t.Errorf("lost %t and %t, want p1 to lose", p0.Lost, p1.Lost)
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+// Damage returns the commands dealing the damage of e, a DealsDamage or
+// DealsCombatDamage event, as modified by replacement effects. There are none
+// when the damage is prevented.
+func (g *Game) Damage(e Event) []Command {
+	g.Replace(&e)
+	if e.Cancelled || e.Amount <= 0 {
+		return nil
+	}
+	dc := &DamageCommand{Event: e}
+	if perm := e.TargetPermanent; perm != nil {
+		ch := g.Characteristics(perm)
+		dc.Planeswalker = ch.Is(Planeswalker)
+	}
+	if e.Permanent != nil {
+		ch := g.Characteristics(e.Permanent)
+		dc.Lifelink, dc.Deathtouch = ch.HasKeyword(Lifelink), ch.HasKeyword(Deathtouch)
+	} else if e.Card != nil {
+		dc.Lifelink, dc.Deathtouch = e.Card.HasKeyword(Lifelink), e.Card.HasKeyword(Deathtouch)
+	}
+	return []Command{dc}
+}
+
+// DamageCommand implements Command.
+var _ Command = (*DamageCommand)(nil)
+
+// DamageCommand deals the damage of Event: a player loses that much life, a
+// planeswalker loses that many loyalty counters and another permanent has it
+// marked. Its controller gains as much life if the source has lifelink, and a
+// permanent dealt damage by a source with deathtouch is noted to be
+// destroyed.
+type DamageCommand struct {
+	Event        Event
+	Lifelink     bool
+	Deathtouch   bool
+	Planeswalker bool
+
+	prevDeathtouched bool
+	prevCounters     Counters
+}
+
+func (dc *DamageCommand) Execute() {
+	e := &dc.Event
+	if p := e.Target; p != nil {
+		p.Life -= e.Amount
+	} else if perm := e.TargetPermanent; dc.Planeswalker {
+		dc.prevCounters = perm.Counters
+		perm.Counters = perm.Counters.Add(LoyaltyCounter, -e.Amount)
+	} else {
+		perm := e.TargetPermanent
+		perm.Damage += e.Amount
+		dc.prevDeathtouched = perm.Deathtouched
+		perm.Deathtouched = perm.Deathtouched || dc.Deathtouch
+	}
+	if dc.Lifelink {
+		e.Player.Life += e.Amount
+	}
+}
+
+func (dc *DamageCommand) Events() []Event {
+	return []Event{dc.Event}
+}
+
+func (dc *DamageCommand) Undo() {
+	e := &dc.Event
+	if dc.Lifelink {
+		e.Player.Life -= e.Amount
+	}
+	if p := e.Target; p != nil {
+		p.Life += e.Amount
+	} else if dc.Planeswalker {
+		e.TargetPermanent.Counters = dc.prevCounters
+	} else {
+		e.TargetPermanent.Damage -= e.Amount
+		e.TargetPermanent.Deathtouched = dc.prevDeathtouched
+	}
+}
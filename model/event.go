@@ -0,0 +1,200 @@
+package model
+
+type EventKind int
+
+const (
+	// Permanent entered the battlefield under Player's control.
+	EntersBattlefield EventKind = iota
+	// Permanent, controlled by Player, was put into a graveyard from the
+	// battlefield.
+	Dies
+	// Permanent, controlled by Player, was declared as an attacker attacking
+	// Target.
+	Attacks
+	// Permanent, controlled by Player, dealt Amount combat damage to Target
+	// or TargetPermanent.
+	DealsCombatDamage
+	// Player's upkeep began.
+	BeginningOfUpkeep
+	// Player cast Card.
+	CastSpell
+	// Permanent, controlled by Player, was exiled from the battlefield.
+	Exiled
+	// Player drew a card.
+	Draws
+	// Permanent or the spell Card, controlled by Player, dealt Amount
+	// noncombat damage to Target or TargetPermanent.
+	DealsDamage
+)
+
+// Event is something that happened in the game, which abilities may trigger
+// on, or that is about to happen, which replacement effects may modify.
+type Event struct {
+	Kind      EventKind
+	Player    *Player
+	Permanent *Permanent
+	Card      *Object
+	Target    *Player
+	// TargetPermanent is the permanent dealt damage, instead of Target.
+	TargetPermanent *Permanent
+	// Amount is the damage dealt.
+	Amount int
+	// Tapped is whether a permanent enters the battlefield tapped.
+	Tapped bool
+	// Cancelled is set when the event is replaced with nothing.
+	Cancelled bool
+}
+
+// EventSource is implemented by commands that make events happen. Game.Execute
+// emits them after executing the command.
+type EventSource interface {
+	Events() []Event
+}
+
+// TriggeredAbility is an ability that triggers on events, after which it is
+// put on the stack the next time a player would receive priority.
+type TriggeredAbility interface {
+	Effect
+	// Triggers reports whether e triggers the ability. c holds the permanent
+	// with the ability and its controller, and is only valid during the call.
+	Triggers(e *Event, c *Context) bool
+}
+
+// Listener is called for every event emitted in a game.
+type Listener func(g *Game, e *Event)
+
+// Listen registers l to be called for every event.
+func (g *Game) Listen(l Listener) {
+	g.Listeners = append(g.Listeners, l)
+}
+
+// Emit makes e happen: listeners are called and the triggered abilities it
+// triggers are noted to be put on the stack. Abilities of a permanent that
+// left the battlefield in e look back in time and trigger as well. When
+// nothing listens and no permanent has triggered abilities, there is nothing
+// to do.
+func (g *Game) Emit(e Event) {
+	if len(g.Listeners) == 0 && !g.mayTrigger(e.Permanent) {
+		return
+	}
+	// Only copies of events that may be seen escape to the heap.
+	ec := e
+	g.emit(&ec)
+}
+
+// mayTrigger reports whether perm or a permanent on the battlefield has
+// triggered abilities.
+func (g *Game) mayTrigger(perm *Permanent) bool {
+	if perm != nil && len(perm.Card.TriggeredAbilities) > 0 {
+		return true
+	}
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			if len(perm.Card.TriggeredAbilities) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Game) emit(e *Event) {
+	for _, l := range g.Listeners {
+		l(g, e)
+	}
+	seen := false
+	// The context is shared by the permanents, which Triggers may not keep.
+	c := &Context{Game: g}
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			seen = seen || perm == e.Permanent
+			g.trigger(e, c, p, perm)
+		}
+	}
+	if e.Permanent != nil && !seen {
+		g.trigger(e, c, e.Player, e.Permanent)
+	}
+}
+
+func (g *Game) trigger(e *Event, c *Context, controller *Player, perm *Permanent) {
+	c.Player, c.Permanent = controller, perm
+	for _, ta := range perm.Card.TriggeredAbilities {
+		if ta.Triggers(e, c) {
+			g.Execute(&triggerCommand{Game: g, Object: &StackObject{
+				Controller: controller,
+				Card:       perm.Card,
+				Source:     perm,
+				Effect:     ta,
+			}})
+		}
+	}
+}
+
+// PutTriggersOnStack puts the abilities that have triggered on the stack, those
+// of the active player first and then in turn order, so that the active
+// player's resolve last. Their controllers choose modes and targets for them;
+// abilities without legal targets are removed.
+func (g *Game) PutTriggersOnStack() {
+	if len(g.Triggered) == 0 {
+		return
+	}
+	for i := range g.Players {
+		p := g.Players[(g.ActivePlayer+i)%len(g.Players)]
+		for _, so := range g.Triggered {
+			if so.Controller != p {
+				continue
+			}
+			if modalOf(so.Effect) != nil || len(targetSpecs(so.Effect)) > 0 {
+				modes, targets, ok := g.chooseModes(&Context{Game: g, Player: p, Permanent: so.Source}, so.Effect)
+				if !ok {
+					continue
+				}
+				so = &StackObject{
+					Controller: so.Controller,
+					Card:       so.Card,
+					Source:     so.Source,
+					Effect:     so.Effect,
+					Targets:    targets,
+					Modes:      modes,
+				}
+			}
+			g.Execute(&pushCommand{Game: g, Object: so})
+		}
+	}
+	g.Execute(&clearTriggeredCommand{Game: g})
+}
+
+// triggerCommand implements Command.
+var _ Command = (*triggerCommand)(nil)
+
+// triggerCommand notes that Object has triggered.
+type triggerCommand struct {
+	Game   *Game
+	Object *StackObject
+}
+
+func (tc *triggerCommand) Execute() {
+	tc.Game.Triggered = append(tc.Game.Triggered, tc.Object)
+}
+
+func (tc *triggerCommand) Undo() {
+	tc.Game.Triggered = tc.Game.Triggered[:len(tc.Game.Triggered)-1]
+}
+
+// clearTriggeredCommand implements Command.
+var _ Command = (*clearTriggeredCommand)(nil)
+
+type clearTriggeredCommand struct {
+	Game *Game
+
+	prev []*StackObject
+}
+
+func (cc *clearTriggeredCommand) Execute() {
+	cc.prev = cc.Game.Triggered
+	cc.Game.Triggered = nil
+}
+
+func (cc *clearTriggeredCommand) Undo() {
+	cc.Game.Triggered = cc.prev
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// onEvent is a triggered ability that triggers on every event of Kind whose
+// player is its controller, and gains them N life.
+type onEvent struct {
+	Kind EventKind
+	gainLife
+}
+
+func (oe *onEvent) Triggers(e *Event, c *Context) bool {
+	return e.Kind == oe.Kind && e.Player == c.Player
+}
+
+func TestTriggers(t *testing.T) {
+	healer := obj(&Card{
+		Name:               "Healer",
+		Type:               Creature,
+		Toughness:          1,
+		TriggeredAbilities: []TriggeredAbility{&onEvent{Kind: EntersBattlefield, gainLife: gainLife{N: 1}}},
+	})
+	watcher := obj(&Card{
+		Name: "Watcher",
+		Type: Enchantment,
+		TriggeredAbilities: []TriggeredAbility{
+			&onEvent{Kind: BeginningOfUpkeep, gainLife: gainLife{N: 2}},
+			&onEvent{Kind: CastSpell, gainLife: gainLife{N: 4}},
+		},
+	})
+	p0 := &Player{First: true, Life: 20, Hand: []*Object{healer}}
+	p1 := &Player{Life: 20}
+	p1.BattleField = []*Permanent{{Controller: p1, Type: Enchantment, Card: watcher}}
+	g := &Game{Players: []*Player{p0, p1}}
+
+	var stackSizes []int
+	p0.Agent = script(func(g *Game, p *Player) Action {
+		if g.CurrentPart != FirstMainPhase {
+			return Action{}
+		}
+		stackSizes = append(stackSizes, len(g.Stack))
+		if len(p.Hand) > 0 {
+			return Action{Card: healer}
+		}
+		return Action{}
+	})
+	var events []EventKind
+	g.Listen(func(g *Game, e *Event) {
+		events = append(events, e.Kind)
+	})
+
+	g.Start()
+	for g.CurrentPart != FirstMainPhase {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p0.Life != 21 || p1.Life != 20 {
+		t.Errorf("life totals %d and %d, want 21 and 20", p0.Life, p1.Life)
+	}
+	// The healer is cast, resolves, and its ability goes on the stack before
+	// p0 gets priority again.
+	if want := []int{0, 1, 1, 0}; !reflect.DeepEqual(stackSizes, want) {
+		t.Errorf("p0 got priority with stack sizes %v, want %v", stackSizes, want)
+	}
+	if want := []EventKind{BeginningOfUpkeep, CastSpell, EntersBattlefield}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events %v, want %v", events, want)
+	}
+
+	for g.ActivePlayer != 1 || g.CurrentPart != DrawStep {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p1.Life != 22 {
+		t.Errorf("upkeep trigger gained %d life, want 2", p1.Life-20)
+	}
+	if len(g.Stack) != 0 || len(g.Triggered) != 0 {
+		t.Errorf("%d objects on the stack and %d triggered left", len(g.Stack), len(g.Triggered))
+	}
+}
+
+func TestTriggerOrder(t *testing.T) {
+	a := obj(&Card{Name: "A", TriggeredAbilities: []TriggeredAbility{&onEvent{Kind: CastSpell}}})
+	b := obj(&Card{Name: "B", TriggeredAbilities: []TriggeredAbility{&onEvent{Kind: CastSpell}}})
+	p0 := &Player{}
+	p1 := &Player{}
+	p0.BattleField = []*Permanent{{Controller: p0, Card: a}}
+	p1.BattleField = []*Permanent{{Controller: p1, Card: b}}
+	g := &Game{Players: []*Player{p0, p1}, ActivePlayer: 1}
+
+	g.Emit(Event{Kind: CastSpell, Player: p0})
+	g.Emit(Event{Kind: CastSpell, Player: p1})
+	g.PutTriggersOnStack()
+	// The active player's ability goes on the stack first and resolves last.
+	if len(g.Stack) != 2 || g.Stack[0].Card != b || g.Stack[1].Card != a {
+		t.Errorf("stack %v, want B then A", g.Stack)
+	}
+	if len(g.Triggered) != 0 {
+		t.Errorf("%d abilities left triggered", len(g.Triggered))
+	}
+}
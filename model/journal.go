@@ -0,0 +1,59 @@
+package model
+
+// Journal records the commands executed in a game, so that the game can be
+// rolled back to a checkpoint and rolled forward again. Search can then
+// explore lines of play on one game instead of copying it for each line.
+type Journal struct {
+	done []Command
+	// undone holds rolled back commands, the most recently undone last.
+	undone []Command
+}
+
+// Checkpoint is a point in the journal to roll back or redo to.
+type Checkpoint int
+
+func (j *Journal) record(c Command) {
+	j.done = append(j.done, c)
+	// Redoing after a new command would replay a different line.
+	j.undone = j.undone[:0]
+}
+
+// Checkpoint returns the current point in the journal, starting the journal
+// if there is none.
+func (g *Game) Checkpoint() Checkpoint {
+	if g.Journal == nil {
+		g.Journal = &Journal{}
+	}
+	return Checkpoint(len(g.Journal.done))
+}
+
+// Rollback undoes the commands executed since cp, latest first.
+func (g *Game) Rollback(cp Checkpoint) {
+	j := g.Journal
+	for Checkpoint(len(j.done)) > cp {
+		c := j.done[len(j.done)-1]
+		j.done = j.done[:len(j.done)-1]
+		c.Undo()
+		j.undone = append(j.undone, c)
+	}
+	g.Invalidate()
+}
+
+// Redo executes again the commands rolled back, until the journal is back at
+// cp or there is nothing left to redo. Events are not emitted again: what they
+// caused is in the journal too.
+func (g *Game) Redo(cp Checkpoint) {
+	j := g.Journal
+	for Checkpoint(len(j.done)) < cp && len(j.undone) > 0 {
+		c := j.undone[len(j.undone)-1]
+		j.undone = j.undone[:len(j.undone)-1]
+		c.Execute()
+		j.done = append(j.done, c)
+	}
+	g.Invalidate()
+}
+
+// StopJournal stops recording commands and forgets the recorded ones.
+func (g *Game) StopJournal() {
+	g.Journal = nil
+}
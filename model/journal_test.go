@@ -0,0 +1,110 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// snapshot is a deep copy of the parts of a game the journal restores.
+type snapshot struct {
+	Part      Part
+	Active    int
+	Stack     []StackObject
+	Triggered int
+	Players   []playerSnapshot
+}
+
+type playerSnapshot struct {
+	Player
+	BattleField []Permanent
+}
+
+func snapshotOf(g *Game) snapshot {
+	s := snapshot{Part: g.CurrentPart, Active: g.ActivePlayer, Triggered: len(g.Triggered)}
+	for _, so := range g.Stack {
+		s.Stack = append(s.Stack, *so)
+	}
+	for _, p := range g.Players {
+		ps := playerSnapshot{Player: *p}
+		ps.Player.BattleField = nil
+		// Functions never compare equal.
+		ps.Player.Agent = nil
+		ps.Player.Hand = append([]*Object(nil), p.Hand...)
+		ps.Player.Library = append([]*Object(nil), p.Library...)
+		ps.Player.GraveYard = append([]*Object(nil), p.GraveYard...)
+		ps.Player.ManaPool = append([]Mana(nil), p.ManaPool...)
+		for _, perm := range p.BattleField {
+			pv := *perm
+			// The cached characteristics are not part of the state.
+			pv.cache = characteristicsCache{}
+			ps.BattleField = append(ps.BattleField, pv)
+		}
+		s.Players = append(s.Players, ps)
+	}
+	return s
+}
+
+func TestJournal(t *testing.T) {
+	plains := obj(&Card{Name: "Plains", Type: Land, ActivatedAbilities: []ActivatedAbility{&addMana{Mana: White}}})
+	healer := obj(&Card{
+		Name:               "Healer",
+		Type:               Creature,
+		Cost:               MustParseManaCost("{W}"),
+		Toughness:          1,
+		TriggeredAbilities: []TriggeredAbility{&onEvent{Kind: EntersBattlefield, gainLife: gainLife{N: 1}}},
+	})
+	p0 := &Player{First: true, Life: 20, Hand: []*Object{plains, healer}, Library: cards(3)}
+	p1 := &Player{Life: 20, Library: cards(3)}
+	g := &Game{Players: []*Player{p0, p1}}
+	p0.Agent = script(func(g *Game, p *Player) Action {
+		switch {
+		case g.CurrentPart != FirstMainPhase || len(p.Hand) == 0:
+			return Action{}
+		case len(p.BattleField) == 0:
+			return Action{Card: plains}
+		case len(p.ManaPool) == 0:
+			land := p.BattleField[0]
+			return Action{Ability: land.Card.ActivatedAbilities[0], Permanent: land}
+		}
+		return Action{Card: healer}
+	})
+
+	g.Start()
+	before := snapshotOf(g)
+	cp := g.Checkpoint()
+	for p1.Turn == 0 {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after := snapshotOf(g)
+	if p0.Life != 21 || len(p0.BattleField) != 2 {
+		t.Fatalf("healer did not resolve: life %d, battlefield %v", p0.Life, p0.BattleField)
+	}
+	end := g.Checkpoint()
+
+	g.Rollback(cp)
+	if got := snapshotOf(g); !reflect.DeepEqual(got, before) {
+		t.Errorf("rolled back to %+v, want %+v", got, before)
+	}
+	g.Redo(end)
+	if got := snapshotOf(g); !reflect.DeepEqual(got, after) {
+		t.Errorf("redone to %+v, want %+v", got, after)
+	}
+
+	// Explore another line: roll back and play nothing this time.
+	g.Rollback(cp)
+	p0.Agent = nil
+	for p1.Turn == 0 {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p0.Life != 20 || len(p0.Hand) != 2 {
+		t.Errorf("life %d and hand %v in the other line", p0.Life, p0.Hand)
+	}
+	g.Redo(end)
+	if g.Checkpoint() == end {
+		t.Errorf("redid the first line over the second")
+	}
+}
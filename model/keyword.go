@@ -0,0 +1,60 @@
+package model
+
+import "strings"
+
+type Keyword int
+
+const (
+	Flying Keyword = iota
+	FirstStrike
+	DoubleStrike
+	Deathtouch
+	Haste
+	Lifelink
+	Vigilance
+	Trample
+	Menace
+	Reach
+	Defender
+	Hexproof
+	Indestructible
+)
+
+var keywordNames = map[Keyword]string{
+	Flying:         "Flying",
+	FirstStrike:    "First strike",
+	DoubleStrike:   "Double strike",
+	Deathtouch:     "Deathtouch",
+	Haste:          "Haste",
+	Lifelink:       "Lifelink",
+	Vigilance:      "Vigilance",
+	Trample:        "Trample",
+	Menace:         "Menace",
+	Reach:          "Reach",
+	Defender:       "Defender",
+	Hexproof:       "Hexproof",
+	Indestructible: "Indestructible",
+}
+
+func (k Keyword) String() string {
+	return keywordNames[k]
+}
+
+// ParseKeyword returns the keyword named s, ignoring case.
+func ParseKeyword(s string) (Keyword, bool) {
+	for k, n := range keywordNames {
+		if strings.EqualFold(n, s) {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Card) HasKeyword(k Keyword) bool {
+	for _, ck := range c.Keywords {
+		if ck == k {
+			return true
+		}
+	}
+	return false
+}
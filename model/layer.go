@@ -0,0 +1,253 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Layer is the order in which continuous effects apply.
+type Layer int
+
+const (
+	CopyLayer Layer = iota + 1
+	ControlLayer
+	TextLayer
+	TypeLayer
+	ColorLayer
+	AbilityLayer
+	// Layer 7 is split into sublayers: characteristic-defining abilities,
+	// effects setting power and toughness, effects modifying them, and
+	// effects switching them.
+	PTDefiningLayer
+	PTSettingLayer
+	PTModifyingLayer
+	PTSwitchingLayer
+)
+
+// Characteristics are the values of a permanent after continuous effects.
+type Characteristics struct {
+	Controller *Player
+	Type       Type
+	SubTypes   []Type
+	Colors     []Mana
+	Keywords   []Keyword
+	Power      int
+	Toughness  int
+}
+
+func (ch *Characteristics) Is(t Type) bool {
+	return ch.Type == t || indexOf(ch.SubTypes, t) >= 0
+}
+
+func (ch *Characteristics) HasColor(m Mana) bool {
+	return indexOf(ch.Colors, m) >= 0
+}
+
+func (ch *Characteristics) HasKeyword(k Keyword) bool {
+	return indexOf(ch.Keywords, k) >= 0
+}
+
+// StaticAbility is an ability of a permanent that generates a continuous
+// effect while the permanent is on the battlefield, e.g. "Other Warrior
+// creatures you control get +1/+0". An ability doing things in several layers
+// is expressed as one StaticAbility per layer.
+type StaticAbility interface {
+	Layer() Layer
+	// Affects reports whether the effect applies to perm, whose
+	// characteristics as of this layer are ch. c holds the permanent with the
+	// ability and its controller.
+	Affects(c *Context, perm *Permanent, ch *Characteristics) bool
+	// Apply modifies ch. Slices of ch may be replaced or appended to, but not
+	// written in place.
+	Apply(ch *Characteristics)
+}
+
+// ContinuousEffect is a static ability of a permanent on the battlefield, or
+// an effect of a resolved spell or ability. Context holds the permanent with
+// the ability, or the source of the effect.
+type ContinuousEffect struct {
+	StaticAbility
+	Context   *Context
+	Timestamp int
+}
+
+// NextTimestamp returns a timestamp later than all the previous ones.
+func (g *Game) NextTimestamp() int {
+	g.Timestamp++
+	return g.Timestamp
+}
+
+// Controller returns the player controlling perm, nil if it is not on the
+// battlefield.
+func (g *Game) Controller(perm *Permanent) *Player {
+	return perm.Controller
+}
+
+// Invalidate notes that the game may have changed, so that characteristics
+// are computed again. Executing and rolling back commands does it; code
+// changing the game directly must call it.
+func (g *Game) Invalidate() {
+	g.version++
+	g.effectsCached = false
+}
+
+// continuousEffects returns the continuous effects in the game sorted by
+// layer and then by timestamp. The result is cached until the game changes.
+func (g *Game) continuousEffects() []ContinuousEffect {
+	if g.effectsCached {
+		return g.effects
+	}
+	// The contexts of static abilities are kept along with the effects, and
+	// overwritten once these are computed again.
+	n := 0
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			if len(perm.Card.StaticAbilities) > 0 {
+				n++
+			}
+		}
+	}
+	if cap(g.contexts) < n {
+		g.contexts = make([]Context, n)
+	}
+	cs := g.contexts[:0]
+	ces := g.effects[:0]
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			if len(perm.Card.StaticAbilities) == 0 {
+				continue
+			}
+			cs = append(cs, Context{Game: g, Player: p, Permanent: perm})
+			c := &cs[len(cs)-1]
+			for _, sa := range perm.Card.StaticAbilities {
+				ces = append(ces, ContinuousEffect{StaticAbility: sa, Context: c, Timestamp: perm.Timestamp})
+			}
+		}
+	}
+	for _, ce := range g.UntilEndOfTurn {
+		ces = append(ces, *ce)
+	}
+	slices.SortStableFunc(ces, func(a, b ContinuousEffect) int {
+		if a.Layer() != b.Layer() {
+			return cmp.Compare(a.Layer(), b.Layer())
+		}
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+	g.effects, g.effectsCached = ces, true
+	return ces
+}
+
+// characteristicsCache holds the characteristics of a permanent as of a
+// version of a game.
+type characteristicsCache struct {
+	game    *Game
+	version int
+	ch      Characteristics
+}
+
+// Characteristics returns the characteristics of perm: those of its card,
+// modified by the continuous effects in the game layer by layer. Within a
+// layer effects apply in timestamp order, except that an effect depending on
+// another applies after it. An effect depends on another when applying the
+// other changes whether it affects perm. +1/+1 and -1/-1 counters on perm
+// modify its power and toughness along with the effects in PTModifyingLayer.
+// The result is cached on perm until the game changes.
+func (g *Game) Characteristics(perm *Permanent) Characteristics {
+	if perm.cache.game != g || perm.cache.version != g.version {
+		// Computing the characteristics in the cache keeps them off the heap.
+		g.characteristics(perm, &perm.cache.ch)
+		perm.cache.game, perm.cache.version = g, g.version
+	}
+	return perm.cache.ch
+}
+
+func (g *Game) characteristics(perm *Permanent, ch *Characteristics) {
+	c := perm.Card
+	colors := c.Colors
+	if colors == nil {
+		colors = c.Cost.Colors()
+	}
+	*ch = Characteristics{
+		Controller: perm.Controller,
+		Type:       perm.Type,
+		SubTypes:   c.SubTypes[:len(c.SubTypes):len(c.SubTypes)],
+		Colors:     colors[:len(colors):len(colors)],
+		Keywords:   c.Keywords[:len(c.Keywords):len(c.Keywords)],
+		Power:      c.Power,
+		Toughness:  c.Toughness,
+	}
+	counters := perm.Counters[PlusOneCounter] - perm.Counters[MinusOneCounter]
+	applyCounters := func() {
+		ch.Power += counters
+		ch.Toughness += counters
+		counters = 0
+	}
+	ces := g.continuousEffects()
+	for i := 0; i < len(ces); {
+		j := i
+		for j < len(ces) && ces[j].Layer() == ces[i].Layer() {
+			j++
+		}
+		layer := ces[i:j]
+		if ces[i].Layer() >= PTModifyingLayer {
+			applyCounters()
+		}
+		// Modifications of power and toughness commute, so they never depend
+		// on each other.
+		if ces[i].Layer() != PTModifyingLayer {
+			layer = orderByDependency(perm, ch, layer)
+		}
+		for _, ce := range layer {
+			if ce.Affects(ce.Context, perm, ch) {
+				ce.Apply(ch)
+			}
+		}
+		i = j
+	}
+	applyCounters()
+}
+
+// orderByDependency returns ces, which are in timestamp order, reordered so
+// that each effect comes after those it depends on. Effects in a dependency
+// loop stay in timestamp order. ces itself, which is cached, is left as is.
+func orderByDependency(perm *Permanent, ch *Characteristics, ces []ContinuousEffect) []ContinuousEffect {
+	if len(ces) < 2 {
+		return ces
+	}
+	dependsOn := func(a, b ContinuousEffect) bool {
+		if !b.Affects(b.Context, perm, ch) {
+			return false
+		}
+		applied := *ch
+		b.Apply(&applied)
+		return a.Affects(a.Context, perm, ch) != a.Affects(a.Context, perm, &applied)
+	}
+	var ordered []ContinuousEffect
+	remaining := ces
+	for len(remaining) > 0 {
+		next := 0
+	candidates:
+		for i, a := range remaining {
+			for k, b := range remaining {
+				if k != i && dependsOn(a, b) && !dependsOn(b, a) {
+					continue candidates
+				}
+			}
+			next = i
+			break
+		}
+		ordered = append(ordered, remaining[next])
+		remaining = without(remaining, next)
+	}
+	return ordered
+}
+
+// Power returns the power of perm after continuous effects.
+func (g *Game) Power(perm *Permanent) int {
+	return g.Characteristics(perm).Power
+}
+
+// Toughness returns the toughness of perm after continuous effects.
+func (g *Game) Toughness(perm *Permanent) int {
+	return g.Characteristics(perm).Toughness
+}
@@ -0,0 +1,117 @@
+package model
+
+import "testing"
+
+// addType makes creatures that are If also T.
+type addType struct {
+	If, T Type
+}
+
+func (at *addType) Layer() Layer { return TypeLayer }
+
+func (at *addType) Affects(c *Context, perm *Permanent, ch *Characteristics) bool {
+	return ch.Is(at.If)
+}
+
+func (at *addType) Apply(ch *Characteristics) {
+	ch.SubTypes = append(ch.SubTypes, at.T)
+}
+
+// pumpType gives creatures of T +Power/+0.
+type pumpType struct {
+	T     Type
+	Power int
+}
+
+func (pt *pumpType) Layer() Layer { return PTModifyingLayer }
+
+func (pt *pumpType) Affects(c *Context, perm *Permanent, ch *Characteristics) bool {
+	return ch.Is(pt.T)
+}
+
+func (pt *pumpType) Apply(ch *Characteristics) {
+	ch.Power += pt.Power
+}
+
+// setPT sets the power and toughness of creatures.
+type setPT struct {
+	Power, Toughness int
+}
+
+func (sp *setPT) Layer() Layer { return PTSettingLayer }
+
+func (sp *setPT) Affects(c *Context, perm *Permanent, ch *Characteristics) bool {
+	return ch.Is(Creature)
+}
+
+func (sp *setPT) Apply(ch *Characteristics) {
+	ch.Power, ch.Toughness = sp.Power, sp.Toughness
+}
+
+func TestCharacteristics(t *testing.T) {
+	bear := obj(&Card{Name: "Bear", Type: Creature, SubTypes: []Type{Cat}, Power: 2, Toughness: 2})
+	p0, p1 := &Player{}, &Player{}
+	g := &Game{Players: []*Player{p0, p1}}
+	enter := func(p *Player, c *Object) *Permanent {
+		perm := &Permanent{Controller: p, Type: c.Type, Card: c, Timestamp: g.NextTimestamp()}
+		p.BattleField = append(p.BattleField, perm)
+		return perm
+	}
+	b := enter(p0, bear)
+
+	// Humans become Worriers, then cats become Humans. The first depends on
+	// the second, so it applies after it despite its earlier timestamp.
+	enter(p1, obj(&Card{Name: "Worrier Maker", StaticAbilities: []StaticAbility{&addType{If: Human, T: Worrier}}}))
+	enter(p1, obj(&Card{Name: "Worrier Anthem", StaticAbilities: []StaticAbility{&pumpType{T: Worrier, Power: 3}}}))
+	enter(p1, obj(&Card{Name: "Human Maker", StaticAbilities: []StaticAbility{&addType{If: Cat, T: Human}}}))
+	// Setting power and toughness applies before modifying them, whatever
+	// the timestamps.
+	enter(p1, obj(&Card{Name: "Shrink", StaticAbilities: []StaticAbility{&setPT{Power: 0, Toughness: 1}}}))
+
+	ch := g.Characteristics(b)
+	if !ch.Is(Human) || !ch.Is(Worrier) {
+		t.Errorf("subtypes %v, want Human and Worrier", ch.SubTypes)
+	}
+	if ch.Power != 3 || ch.Toughness != 1 {
+		t.Errorf("bear is %d/%d, want 3/1", ch.Power, ch.Toughness)
+	}
+	if ch.Controller != p0 {
+		t.Errorf("controller is not p0")
+	}
+	if len(bear.SubTypes) != 1 {
+		t.Errorf("effects changed the card: %v", bear.SubTypes)
+	}
+}
+
+func TestCharacteristicsCache(t *testing.T) {
+	p := &Player{}
+	g := &Game{Players: []*Player{p}}
+	enter := func(c *Card) *Permanent {
+		o := g.NewObject(c, p)
+		p.Hand = append(p.Hand, o)
+		perm := g.Entering(p, o)
+		g.Execute(&MoveCommand{Game: g, Player: p, Card: o, Entering: perm, From: HandZone, To: BattlefieldZone})
+		return perm
+	}
+	bear := enter(&Card{Name: "Bear", Type: Creature, SubTypes: []Type{Cat}, Power: 2, Toughness: 2})
+	if g.Power(bear) != 2 || g.Controller(bear) != p {
+		t.Fatalf("power %d", g.Power(bear))
+	}
+
+	cp := g.Checkpoint()
+	anthem := enter(&Card{Name: "Cat Anthem", StaticAbilities: []StaticAbility{&pumpType{T: Cat, Power: 1}}})
+	if g.Power(bear) != 3 {
+		t.Errorf("power %d after the anthem entered, want 3", g.Power(bear))
+	}
+	g.Rollback(cp)
+	if g.Power(bear) != 2 || g.Controller(anthem) != nil {
+		t.Errorf("power %d after rollback, want 2", g.Power(bear))
+	}
+
+	// Changes made directly are seen once the game is invalidated.
+	g.UntilEndOfTurn = []*ContinuousEffect{{StaticAbility: &setPT{Power: 5, Toughness: 5}}}
+	g.Invalidate()
+	if g.Power(bear) != 5 {
+		t.Errorf("power %d after invalidation, want 5", g.Power(bear))
+	}
+}
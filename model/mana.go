@@ -0,0 +1,315 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Mana int
+
+const (
+	// Any stands for mana of any one color, as produced by e.g. "Add one mana
+	// of any color". Generic costs are GenericSymbol instead.
+	Any Mana = iota
+	White
+	Blue
+	Black
+	Red
+	Green
+	Colorless
+)
+
+var manaLetters = map[Mana]string{
+	White:     "W",
+	Blue:      "U",
+	Black:     "B",
+	Red:       "R",
+	Green:     "G",
+	Colorless: "C",
+}
+
+func (m Mana) String() string {
+	if l, ok := manaLetters[m]; ok {
+		return l
+	}
+	return "*"
+}
+
+// IsColor reports whether m is one of the five colors.
+func (m Mana) IsColor() bool {
+	return White <= m && m <= Green
+}
+
+func parseColor(s string) (Mana, bool) {
+	for m, l := range manaLetters {
+		if l == s && m.IsColor() {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+type SymbolKind int
+
+const (
+	// {2}
+	GenericSymbol SymbolKind = iota
+	// {W}
+	ColoredSymbol
+	// {C}
+	ColorlessSymbol
+	// {X}
+	XSymbol
+	// {W/B}
+	HybridSymbol
+	// {2/W}
+	TwoHybridSymbol
+	// {W/P}
+	PhyrexianSymbol
+)
+
+// ManaSymbol is one symbol of a mana cost.
+type ManaSymbol struct {
+	Kind SymbolKind
+	// Amount is the generic mana of a GenericSymbol.
+	Amount int
+	// Color is the color of a ColoredSymbol, PhyrexianSymbol or
+	// TwoHybridSymbol, and the first color of a HybridSymbol.
+	Color Mana
+	// Other is the second color of a HybridSymbol.
+	Other Mana
+}
+
+func (s ManaSymbol) String() string {
+	switch s.Kind {
+	case GenericSymbol:
+		return "{" + strconv.Itoa(s.Amount) + "}"
+	case ColoredSymbol:
+		return "{" + s.Color.String() + "}"
+	case ColorlessSymbol:
+		return "{C}"
+	case XSymbol:
+		return "{X}"
+	case HybridSymbol:
+		return "{" + s.Color.String() + "/" + s.Other.String() + "}"
+	case TwoHybridSymbol:
+		return "{2/" + s.Color.String() + "}"
+	case PhyrexianSymbol:
+		return "{" + s.Color.String() + "/P}"
+	}
+	return "{?}"
+}
+
+// Value is the symbol's contribution to the mana value of a cost.
+func (s ManaSymbol) Value() int {
+	switch s.Kind {
+	case GenericSymbol:
+		return s.Amount
+	case XSymbol:
+		return 0
+	case TwoHybridSymbol:
+		return 2
+	}
+	return 1
+}
+
+// ManaCost is a mana cost in the order it is printed, e.g. {2}{W}{B}.
+type ManaCost []ManaSymbol
+
+// ParseManaCost parses standard mana notation such as "{2}{W}{B}", "{X}{R}",
+// "{W/B}", "{2/W}", "{C}" or "{W/P}". The empty string is the empty cost.
+func ParseManaCost(s string) (ManaCost, error) {
+	var mc ManaCost
+	for rest := s; rest != ""; {
+		if rest[0] != '{' {
+			return nil, fmt.Errorf("malformed mana cost %q", s)
+		}
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, fmt.Errorf("malformed mana cost %q", s)
+		}
+		sym, err := parseSymbol(rest[1:end])
+		if err != nil {
+			return nil, fmt.Errorf("mana cost %q: %v", s, err)
+		}
+		mc = append(mc, sym)
+		rest = rest[end+1:]
+	}
+	return mc, nil
+}
+
+func parseSymbol(s string) (ManaSymbol, error) {
+	switch s {
+	case "X":
+		return ManaSymbol{Kind: XSymbol}, nil
+	case "C":
+		return ManaSymbol{Kind: ColorlessSymbol}, nil
+	}
+	if c, ok := parseColor(s); ok {
+		return ManaSymbol{Kind: ColoredSymbol, Color: c}, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return ManaSymbol{Kind: GenericSymbol, Amount: n}, nil
+	}
+	if a, b, ok := strings.Cut(s, "/"); ok {
+		c, cok := parseColor(a)
+		switch {
+		case cok && b == "P":
+			return ManaSymbol{Kind: PhyrexianSymbol, Color: c}, nil
+		case a == "2":
+			if o, ok := parseColor(b); ok {
+				return ManaSymbol{Kind: TwoHybridSymbol, Color: o}, nil
+			}
+		case cok:
+			if o, ok := parseColor(b); ok && o != c {
+				return ManaSymbol{Kind: HybridSymbol, Color: c, Other: o}, nil
+			}
+		}
+	}
+	return ManaSymbol{}, fmt.Errorf("unsupported mana symbol {%s}", s)
+}
+
+// MustParseManaCost is like ParseManaCost but panics on error. It is meant
+// for card definitions written in code.
+func MustParseManaCost(s string) ManaCost {
+	mc, err := ParseManaCost(s)
+	if err != nil {
+		panic(err)
+	}
+	return mc
+}
+
+func (mc ManaCost) String() string {
+	var b strings.Builder
+	for _, s := range mc {
+		b.WriteString(s.String())
+	}
+	return b.String()
+}
+
+// Value is the mana value of the cost, counting X as zero.
+func (mc ManaCost) Value() int {
+	var v int
+	for _, s := range mc {
+		v += s.Value()
+	}
+	return v
+}
+
+// colorSet returns which colors the cost includes.
+func (mc ManaCost) colorSet() [Green + 1]bool {
+	var has [Green + 1]bool
+	for _, s := range mc {
+		switch s.Kind {
+		case ColoredSymbol, TwoHybridSymbol, PhyrexianSymbol:
+			has[s.Color] = true
+		case HybridSymbol:
+			has[s.Color] = true
+			has[s.Other] = true
+		}
+	}
+	return has
+}
+
+// colorLists holds the list of colors in WUBRG order for each set of colors,
+// indexed by a bit per color.
+var colorLists [1 << 5][]Mana
+
+func init() {
+	for set := range colorLists {
+		var cs []Mana
+		for m := White; m <= Green; m++ {
+			if set&(1<<(m-White)) != 0 {
+				cs = append(cs, m)
+			}
+		}
+		colorLists[set] = cs[:len(cs):len(cs)]
+	}
+}
+
+// Colors returns the colors of the cost in WUBRG order. The result is shared
+// and must not be written to.
+func (mc ManaCost) Colors() []Mana {
+	has := mc.colorSet()
+	set := 0
+	for m := White; m <= Green; m++ {
+		if has[m] {
+			set |= 1 << (m - White)
+		}
+	}
+	return colorLists[set]
+}
+
+// HasColor reports whether the cost includes m.
+func (mc ManaCost) HasColor(m Mana) bool {
+	return m.IsColor() && mc.colorSet()[m]
+}
+
+// Pay returns what is left of pool after paying the cost from it, and whether
+// it could be paid. X is paid as zero and Phyrexian symbols only with mana.
+// Symbols that leave no choice are paid first, then hybrid symbols are paid
+// in each possible way until the rest of the pool covers the generic mana.
+// A hybrid symbol is preferably paid with its first color.
+func (mc ManaCost) Pay(pool []Mana) ([]Mana, bool) {
+	rest := append([]Mana(nil), pool...)
+	take := func(m Mana) bool {
+		for i, r := range rest {
+			if r == m {
+				rest = append(rest[:i], rest[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+	generic := 0
+	var hybrids []ManaSymbol
+	for _, s := range mc {
+		switch s.Kind {
+		case GenericSymbol:
+			generic += s.Amount
+		case ColoredSymbol, PhyrexianSymbol:
+			if !take(s.Color) {
+				return pool, false
+			}
+		case ColorlessSymbol:
+			if !take(Colorless) {
+				return pool, false
+			}
+		case HybridSymbol, TwoHybridSymbol:
+			hybrids = append(hybrids, s)
+		}
+	}
+	if rest, ok := payHybrids(hybrids, rest, generic); ok {
+		return rest, true
+	}
+	return pool, false
+}
+
+// payHybrids returns what is left of rest after paying hs and generic mana
+// from it, trying the ways to pay hs in turn. rest is left untouched.
+func payHybrids(hs []ManaSymbol, rest []Mana, generic int) ([]Mana, bool) {
+	if len(hs) == 0 {
+		if generic > len(rest) {
+			return nil, false
+		}
+		return rest[generic:], true
+	}
+	s := hs[0]
+	colors := [2]Mana{s.Color, s.Other}
+	n := 1
+	if s.Kind == HybridSymbol {
+		n = 2
+	}
+	for _, m := range colors[:n] {
+		if i := indexOf(rest, m); i >= 0 {
+			if r, ok := payHybrids(hs[1:], without(rest, i), generic); ok {
+				return r, true
+			}
+		}
+	}
+	if s.Kind == TwoHybridSymbol {
+		return payHybrids(hs[1:], rest, generic+2)
+	}
+	return nil, false
+}
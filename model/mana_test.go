@@ -0,0 +1,90 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseManaCost(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		want   ManaCost
+		value  int
+		colors []Mana
+	}{
+		{"", nil, 0, nil},
+		{"{2}{W}{B}", ManaCost{
+			{Kind: GenericSymbol, Amount: 2},
+			{Kind: ColoredSymbol, Color: White},
+			{Kind: ColoredSymbol, Color: Black},
+		}, 4, []Mana{White, Black}},
+		{"{X}{X}{R}", ManaCost{{Kind: XSymbol}, {Kind: XSymbol}, {Kind: ColoredSymbol, Color: Red}}, 1, []Mana{Red}},
+		{"{W/B}{W/B}", ManaCost{
+			{Kind: HybridSymbol, Color: White, Other: Black},
+			{Kind: HybridSymbol, Color: White, Other: Black},
+		}, 2, []Mana{White, Black}},
+		{"{2/G}", ManaCost{{Kind: TwoHybridSymbol, Color: Green}}, 2, []Mana{Green}},
+		{"{3}{C}", ManaCost{{Kind: GenericSymbol, Amount: 3}, {Kind: ColorlessSymbol}}, 4, nil},
+		{"{1}{U/P}", ManaCost{{Kind: GenericSymbol, Amount: 1}, {Kind: PhyrexianSymbol, Color: Blue}}, 2, []Mana{Blue}},
+		{"{0}", ManaCost{{Kind: GenericSymbol}}, 0, nil},
+		{"{G}{R}{U}", ManaCost{
+			{Kind: ColoredSymbol, Color: Green},
+			{Kind: ColoredSymbol, Color: Red},
+			{Kind: ColoredSymbol, Color: Blue},
+		}, 3, []Mana{Blue, Red, Green}},
+	} {
+		got, err := ParseManaCost(tc.in)
+		if err != nil {
+			t.Errorf("ParseManaCost(%q): %v", tc.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseManaCost(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+		if s := got.String(); s != tc.in {
+			t.Errorf("ParseManaCost(%q).String() = %q", tc.in, s)
+		}
+		if v := got.Value(); v != tc.value {
+			t.Errorf("ParseManaCost(%q).Value() = %d, want %d", tc.in, v, tc.value)
+		}
+		if cs := got.Colors(); !reflect.DeepEqual(cs, tc.colors) {
+			t.Errorf("ParseManaCost(%q).Colors() = %v, want %v", tc.in, cs, tc.colors)
+		}
+	}
+}
+
+func TestParseManaCostErrors(t *testing.T) {
+	for _, in := range []string{"W", "{W", "{Q}", "{W/W}", "{-1}", "{S}", "{2}x"} {
+		if got, err := ParseManaCost(in); err == nil {
+			t.Errorf("ParseManaCost(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestManaCostPay(t *testing.T) {
+	for _, tc := range []struct {
+		cost string
+		pool []Mana
+		rest []Mana
+		ok   bool
+	}{
+		{"{1}{W}", []Mana{Black, White, Red}, []Mana{Red}, true},
+		{"{W}{W}", []Mana{White, Black}, nil, false},
+		{"{W/B}", []Mana{White, Black}, []Mana{Black}, true},
+		// Paying {W/B} with the first white leaves nothing for {W/U}.
+		{"{W/B}{W/U}", []Mana{White, Black}, []Mana{}, true},
+		// Paying {2/W} with white leaves nothing for {W/B}.
+		{"{2/W}{W/B}", []Mana{White, Colorless, Colorless}, []Mana{}, true},
+		{"{2/W}{W/B}", []Mana{White, Colorless}, nil, false},
+		{"{1}{R/G}{R/G}", []Mana{Green, Red, Blue}, []Mana{}, true},
+	} {
+		mc, err := ParseManaCost(tc.cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rest, ok := mc.Pay(tc.pool)
+		if ok != tc.ok || ok && !reflect.DeepEqual(rest, tc.rest) {
+			t.Errorf("%s.Pay(%v) = %v, %t, want %v, %t", tc.cost, tc.pool, rest, ok, tc.rest, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package model
+
+import "fmt"
+
+// Mode is one of the modes of a Modal effect.
+type Mode struct {
+	// Text describes the mode, e.g. "Deal 4 damage to target creature."
+	Text   string
+	Effect Effect
+}
+
+// ModalEffect is implemented by effects that are modal, or hold one that is
+// such as a triggered ability: Modal returns it, nil if there is none.
+type ModalEffect interface {
+	Modal() *Modal
+}
+
+// modalOf returns the Modal of e, nil if it is not modal.
+func modalOf(e Effect) *Modal {
+	if me, ok := e.(ModalEffect); ok {
+		return me.Modal()
+	}
+	return nil
+}
+
+// Modal implements Effect and ModalEffect.
+var (
+	_ Effect      = (*Modal)(nil)
+	_ ModalEffect = (*Modal)(nil)
+)
+
+// Modal is the effect of a modal spell or triggered ability, of whose Modes
+// its controller chooses at least Min and at most Max: "Choose one —" is Min
+// and Max 1, "Choose one or more —" Min 1 and Max len(Modes), and "Choose up
+// to two —" Min 0 and Max 2. The modes are chosen as the spell is cast or the
+// ability put on the stack, and recorded on the stack object. Each chosen
+// mode has its own targets, which follow those of the modes before it.
+type Modal struct {
+	Min, Max int
+	Modes    []Mode
+}
+
+func (m *Modal) Modal() *Modal {
+	return m
+}
+
+// Commands returns the commands of the modes in c.Modes, each with its own
+// targets.
+func (m *Modal) Commands(c *Context) []Command {
+	targets := c.Targets
+	var cmds []Command
+	for _, i := range c.Modes {
+		e := m.Modes[i].Effect
+		n := len(targetSpecs(e))
+		mc := *c
+		mc.Modes, mc.Targets, targets = nil, targets[:n:n], targets[n:]
+		cmds = append(cmds, e.Commands(&mc)...)
+	}
+	return cmds
+}
+
+// texts returns the texts of the modes, as agents are asked to choose among.
+func (m *Modal) texts() []string {
+	ts := make([]string, len(m.Modes))
+	for i, mode := range m.Modes {
+		ts[i] = mode.Text
+	}
+	return ts
+}
+
+// check returns an error unless modes are a legal choice: between Min and
+// Max modes, each chosen once, in the order they are printed.
+func (m *Modal) check(modes []int) error {
+	if len(modes) < m.Min || len(modes) > m.Max {
+		return fmt.Errorf("%d modes chosen, want %d to %d", len(modes), m.Min, m.Max)
+	}
+	for i, mode := range modes {
+		if mode < 0 || mode >= len(m.Modes) || i > 0 && mode <= modes[i-1] {
+			return fmt.Errorf("illegal modes %v", modes)
+		}
+	}
+	return nil
+}
+
+// combinations returns every legal choice of modes.
+func (m *Modal) combinations() [][]int {
+	var mss [][]int
+	var choose func(next int, chosen []int)
+	choose = func(next int, chosen []int) {
+		if len(chosen) >= m.Min {
+			mss = append(mss, chosen)
+		}
+		if len(chosen) == m.Max {
+			return
+		}
+		for i := next; i < len(m.Modes); i++ {
+			choose(i+1, append(chosen[:len(chosen):len(chosen)], i))
+		}
+	}
+	choose(0, nil)
+	return mss
+}
+
+// modeSpecs returns the target specs of e with modes chosen: those of each
+// chosen mode in turn for a Modal effect.
+func modeSpecs(e Effect, modes []int) []TargetSpec {
+	m := modalOf(e)
+	if m == nil {
+		return targetSpecs(e)
+	}
+	var specs []TargetSpec
+	for _, i := range modes {
+		specs = append(specs, targetSpecs(m.Modes[i].Effect)...)
+	}
+	return specs
+}
+
+// chooseModes has c.Player choose modes of e, and targets for them. An
+// illegal choice of the agent is rejected for the first Min modes. It reports
+// whether each target has a legal choice. Effects other than Modal only have
+// targets chosen.
+func (g *Game) chooseModes(c *Context, e Effect) ([]int, []Target, bool) {
+	m := modalOf(e)
+	if m == nil {
+		targets, ok := g.chooseTargets(c, targetSpecs(e))
+		return nil, targets, ok
+	}
+	modes := c.Player.agent().ChooseModes(g, c.Player, m.texts(), m.Min, m.Max)
+	if err := m.check(modes); err != nil {
+		g.reject(c.Player, err)
+		modes = Passive{}.ChooseModes(g, c.Player, m.texts(), m.Min, m.Max)
+	}
+	targets, ok := g.chooseTargets(c, modeSpecs(m, modes))
+	return modes, targets, ok
+}
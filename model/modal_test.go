@@ -0,0 +1,125 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModalCombinations(t *testing.T) {
+	modes := []Mode{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+	for _, tc := range []struct {
+		min, max int
+		want     [][]int
+	}{
+		{1, 1, [][]int{{0}, {1}, {2}}},
+		{1, 3, [][]int{{0}, {0, 1}, {0, 1, 2}, {0, 2}, {1}, {1, 2}, {2}}},
+		{0, 1, [][]int{nil, {0}, {1}, {2}}},
+	} {
+		m := &Modal{Min: tc.min, Max: tc.max, Modes: modes}
+		if got := m.combinations(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("combinations of %d to %d = %v, want %v", tc.min, tc.max, got, tc.want)
+		}
+		for _, ms := range tc.want {
+			if err := m.check(ms); err != nil {
+				t.Errorf("check(%v) of %d to %d: %v", ms, tc.min, tc.max, err)
+			}
+		}
+	}
+}
+
+func TestModalSpell(t *testing.T) {
+	p0 := &Player{Life: 20}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}}
+	charm := &Card{Name: "Charm", Type: Instant, Spell: &Modal{Min: 1, Max: 2, Modes: []Mode{
+		{Text: "Gain 2 life.", Effect: &gainLife{N: 2}},
+		{Text: "Target player loses 3 life.", Effect: &drain{Specs: []TargetSpec{&anyPlayer{}}, N: 3}},
+	}}}
+	c := g.NewObject(charm, p0)
+	p0.Hand = []*Object{c}
+
+	// [0] with no targets, [0 1] and [1] with one of two players each.
+	if as := g.LegalActions(p0); len(as) != 5 || !reflect.DeepEqual(as[1].Modes, []int{0, 1}) {
+		t.Errorf("legal actions %v", as)
+	}
+	for _, modes := range [][]int{nil, {1, 0}, {0, 0}, {2}} {
+		if err := g.CastModes(p0, c, modes); err == nil {
+			t.Errorf("cast with modes %v", modes)
+		}
+	}
+	if err := g.CastModes(p0, c, []int{0, 1}); err == nil {
+		t.Errorf("cast without the target of a mode")
+	}
+	if err := g.Perform(p0, Action{Card: c, Modes: []int{0, 1}, Targets: []Target{{Player: p1}}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Stack) != 1 || !reflect.DeepEqual(g.Stack[0].Modes, []int{0, 1}) {
+		t.Fatalf("stack %v", g.Stack)
+	}
+	g.Resolve()
+	if p0.Life != 22 || p1.Life != 17 {
+		t.Errorf("life totals %d and %d, want 22 and 17", p0.Life, p1.Life)
+	}
+
+	// A spell that is not modal has no modes.
+	p0.Hand = []*Object{obj(&Card{Name: "Heal", Type: Instant, Spell: &gainLife{N: 1}})}
+	if err := g.CastModes(p0, p0.Hand[0], []int{0}); err == nil {
+		t.Errorf("cast a spell that is not modal with modes")
+	}
+}
+
+// modalTrigger is a modal ability triggering on its controller's spells.
+type modalTrigger struct {
+	modal *Modal
+}
+
+func (mt *modalTrigger) Modal() *Modal { return mt.modal }
+
+func (mt *modalTrigger) Commands(c *Context) []Command { return mt.modal.Commands(c) }
+
+func (mt *modalTrigger) Triggers(e *Event, c *Context) bool {
+	return e.Kind == CastSpell && e.Player == c.Player
+}
+
+// chooseModes is an agent choosing modes.
+type chooseModes struct {
+	Passive
+	modes []int
+}
+
+func (cm chooseModes) ChooseModes(g *Game, p *Player, modes []string, min, max int) []int {
+	return cm.modes
+}
+
+func TestModalTrigger(t *testing.T) {
+	p0 := &Player{Life: 20, Agent: chooseModes{modes: []int{1}}}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}}
+	ta := &modalTrigger{&Modal{Min: 1, Max: 1, Modes: []Mode{
+		{Text: "Gain 2 life.", Effect: &gainLife{N: 2}},
+		{Text: "Target player loses 3 life.", Effect: &drain{Specs: []TargetSpec{&anyPlayer{}}, N: 3}},
+	}}}
+	p0.BattleField = []*Permanent{{Controller: p0, Card: obj(&Card{Name: "Charmer", TriggeredAbilities: []TriggeredAbility{ta}})}}
+
+	g.Emit(Event{Kind: CastSpell, Player: p0})
+	g.PutTriggersOnStack()
+	if len(g.Stack) != 1 || !reflect.DeepEqual(g.Stack[0].Modes, []int{1}) || !reflect.DeepEqual(g.Stack[0].Targets, []Target{{Player: p0}}) {
+		t.Fatalf("stack %v", g.Stack)
+	}
+	g.Resolve()
+	if p0.Life != 17 {
+		t.Errorf("life %d, want 17", p0.Life)
+	}
+
+	// An illegal choice is rejected for the first mode.
+	p0.Agent = chooseModes{modes: []int{0, 1}}
+	g.Emit(Event{Kind: CastSpell, Player: p0})
+	g.PutTriggersOnStack()
+	if err := g.rejection(); err == nil {
+		t.Errorf("illegal modes were not rejected")
+	}
+	g.Resolve()
+	if p0.Life != 19 {
+		t.Errorf("life %d, want 19", p0.Life)
+	}
+}
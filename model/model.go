@@ -32,30 +32,65 @@ type Game struct {
 	Players      []*Player
 	ActivePlayer int
 	CurrentPart  Part
+	Hooks        [CleanupStep + 1][]Hook
+	// Stack holds spells and abilities waiting to resolve, the top last.
+	Stack []*StackObject
+	// Triggered holds abilities that triggered but are not on the stack yet.
+	Triggered []*StackObject
+	Listeners []Listener
+	// Timestamp is the latest timestamp handed out by NextTimestamp.
+	Timestamp int
+	// UntilEndOfTurn holds the continuous effects of resolved spells and
+	// abilities, which end in the cleanup step.
+	UntilEndOfTurn []*ContinuousEffect
+	// Journal records executed commands once a checkpoint is taken.
+	Journal *Journal
+	// Combat is the state of the combat phase, nil outside of it.
+	Combat *Combat
+	// LastID is the latest ID handed out by NewObject.
+	LastID int
+
+	// version changes whenever the game may have changed, see Invalidate.
+	version int
+	// effects caches continuousEffects while effectsCached is set, and
+	// contexts holds the contexts of their static abilities.
+	effects       []ContinuousEffect
+	effectsCached bool
+	contexts      []Context
+	// rejected is the first illegal choice of an agent, see reject.
+	rejected error
 }
 
 type Player struct {
 	Turn        int
 	First       bool
 	Life        int
-	Library     []*Card
-	Hand        []*Card
+	Library     []*Object
+	Hand        []*Object
 	BattleField []*Permanent
-	GraveYard   []*Card
-	ManaPool    []Mana
+	GraveYard   []*Object
+	Exile       []*Object
+	// Command holds the cards in the command zone, e.g. a commander.
+	Command  []*Object
+	ManaPool []Mana
+	// DrewFromEmptyLibrary records an attempt to draw with an empty library,
+	// which loses the game.
+	DrewFromEmptyLibrary bool
+	LandsPlayed          int
+	// Lost is set when the player loses the game.
+	Lost bool
+	// Attacked records whether the player attacked this turn, for raid.
+	Attacked bool
+	// TokensCreated is the number of tokens the player created this turn.
+	TokensCreated int
+	// Counters are the counters the player has, e.g. poison counters.
+	Counters Counters
+	Agent    Agent
+	// SkipsTurns is set for a player who skips all of their turns, such as
+	// the opponent in a goldfish game.
+	SkipsTurns bool
 }
 
-type Mana int
-
-const (
-	Any Mana = iota
-	White
-	Blue
-	Black
-	Red
-	Green
-)
-
 type Type int
 
 const (
@@ -73,6 +108,8 @@ const (
 	Worrier
 	Demon
 	Cat
+	Orc
+	Dragon
 
 	// Basic land types.
 	Plains
@@ -82,32 +119,133 @@ const (
 	Forest
 )
 
+// Card is the definition of a card, shared by all the copies of it, e.g. one
+// Plains for every Plains in a deck. In a game, each copy is an Object.
 type Card struct {
-	Name               string
-	Type               Type
-	SubTypes           []Type
-	Cost               []Mana
-	Power              int
-	Toughness          int
+	Name string
+	Type Type
+	// SubTypes also holds the other card types of a card with several, e.g.
+	// Artifact for an artifact creature.
+	SubTypes []Type
+	Cost     ManaCost
+	// Colors, when set, are the colors of a card or token other than those
+	// of its mana cost, e.g. given by the effect creating a token.
+	Colors    []Mana
+	Power     int
+	Toughness int
+	// Loyalty is the loyalty a planeswalker enters the battlefield with.
+	Loyalty            int
+	Text               string
+	Keywords           []Keyword
+	EntersTapped       bool
+	Token              bool
+	Legendary          bool
 	ActivatedAbilities []ActivatedAbility
+	TriggeredAbilities []TriggeredAbility
+	StaticAbilities    []StaticAbility
+	ReplacementEffects []ReplacementEffect
+	// Spell is what an instant or sorcery does as it resolves.
+	Spell Effect
+}
+
+// Is reports whether c has type t, as its card type or among its subtypes.
+func (c *Card) Is(t Type) bool {
+	if c.Type == t {
+		return true
+	}
+	for _, st := range c.SubTypes {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Object is a card or token in a game: an instance of the definition Card,
+// whose fields it shares. ID tells apart copies of the same card.
+type Object struct {
+	*Card
+	ID    int
+	Owner *Player
+}
+
+// NewObject returns a new object of c owned by owner.
+func (g *Game) NewObject(c *Card, owner *Player) *Object {
+	g.LastID++
+	return &Object{Card: c, ID: g.LastID, Owner: owner}
+}
+
+// NewObjects returns new objects of cs owned by owner.
+func (g *Game) NewObjects(owner *Player, cs ...*Card) []*Object {
+	os := make([]*Object, len(cs))
+	for i, c := range cs {
+		os[i] = g.NewObject(c, owner)
+	}
+	return os
 }
 
 type Permanent struct {
-	Type   Type
-	Card   *Card
-	Tapped bool
+	Type Type
+	Card *Object
+	// Controller is the player controlling the permanent. It is set as the
+	// permanent enters the battlefield and cleared as it leaves.
+	Controller    *Player
+	Tapped        bool
+	SummoningSick bool
+	// Timestamp orders the continuous effects of the permanent's static
+	// abilities.
+	Timestamp int
+	// Damage is the damage marked on the permanent this turn.
+	Damage int
+	// Deathtouched is set when the permanent is dealt damage by a source
+	// with deathtouch.
+	Deathtouched bool
+	Counters     Counters
+	// LoyaltyActivated is set once a loyalty ability of the permanent has
+	// been activated this turn, which only one may be.
+	LoyaltyActivated bool
+
+	cache characteristicsCache
 }
 
 type Context struct {
 	Game      *Game
 	Player    *Player
 	Permanent *Permanent
+	// Card is the spell resolving, nil for abilities.
+	Card *Object
+	// Targets are the targets of the spell or ability, see Targeted.
+	Targets []Target
+	// Modes are the modes chosen for a modal spell or ability, see Modal.
+	Modes []int
 }
 
-type ActivatedAbility interface {
+// Effect is what a spell or ability does.
+type Effect interface {
 	Commands(c *Context) []Command
 }
 
+type ActivatedAbility interface {
+	Effect
+	// Cost returns what activating the ability costs, nil if nothing.
+	Cost() Cost
+}
+
+// Restricted is implemented by activated abilities that may only be
+// activated at certain times, e.g. "Activate only as a sorcery."
+type Restricted interface {
+	// CanActivate reports whether the ability of c.Permanent may be activated
+	// by c.Player now, leaving aside its cost.
+	CanActivate(c *Context) bool
+}
+
+// ManaAbility is an activated ability that produces mana. It resolves right
+// away instead of using the stack.
+type ManaAbility interface {
+	ActivatedAbility
+	IsManaAbility()
+}
+
 type Command interface {
 	Execute()
 	Undo()
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjects(t *testing.T) {
+	plains := &Card{Name: "Plains", Type: Land}
+	p0, p1 := &Player{}, &Player{}
+	g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+	p0.Hand = g.NewObjects(p0, plains, plains)
+	a, b := p0.Hand[0], p0.Hand[1]
+	if a == b || a.ID == b.ID || a.Card != b.Card || a.Owner != p0 {
+		t.Fatalf("objects %+v and %+v", a, b)
+	}
+	if o := g.NewObject(plains, p1); o.ID <= b.ID || o.Owner != p1 {
+		t.Errorf("object %+v after %+v", o, b)
+	}
+
+	// The two Plains are told apart.
+	if err := g.PlayLand(p0, b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p0.Hand, []*Object{a}) || p0.BattleField[0].Card != b {
+		t.Errorf("hand %v and battlefield %v after playing the second Plains", p0.Hand, p0.BattleField)
+	}
+}
@@ -0,0 +1,167 @@
+package model
+
+import "fmt"
+
+// MoveCommand implements Command.
+var _ Command = (*MoveCommand)(nil)
+
+// MoveCommand moves Card from zone From to zone To, at Position of To (see
+// Top and Bottom), and is how cards change zones. A card put onto the
+// battlefield or the stack comes under Player's control. Other zones are those
+// of Card's owner, except the battlefield it leaves, which is its
+// controller's.
+//
+// Card keeps its Object and ID in every zone. On the battlefield it is a
+// Permanent instead: a card leaving the battlefield leaves Permanent behind,
+// and one entering it becomes Entering. A token leaving the battlefield ceases
+// to exist. Undo puts everything back where it was, including the positions of
+// cards in their zones. Execute panics when Card is not in From.
+type MoveCommand struct {
+	Game   *Game
+	Player *Player
+	Card   *Object
+	// Permanent is Card on the battlefield, when From is the battlefield.
+	Permanent *Permanent
+	// Entering is the permanent Card becomes, when To is the battlefield.
+	// Get it from Game.Entering, which applies the replacement effects to
+	// its entering.
+	Entering *Permanent
+	From, To Zone
+	Position int
+
+	fromPlayer, toPlayer *Player
+	from, to             zoneContents
+	timestamp            int
+	// dies is whether Card is a creature put into a graveyard from the
+	// battlefield.
+	dies bool
+}
+
+// zoneContents is what a zone held before a move.
+type zoneContents struct {
+	cards []*Object
+	perms []*Permanent
+	stack []*StackObject
+}
+
+func (mc *MoveCommand) save(p *Player, z Zone) zoneContents {
+	switch z {
+	case BattlefieldZone:
+		return zoneContents{perms: p.BattleField}
+	case StackZone:
+		return zoneContents{stack: mc.Game.Stack}
+	}
+	return zoneContents{cards: *p.Cards(z)}
+}
+
+func (mc *MoveCommand) restore(p *Player, z Zone, zc zoneContents) {
+	switch z {
+	case BattlefieldZone:
+		p.BattleField = zc.perms
+	case StackZone:
+		mc.Game.Stack = zc.stack
+	default:
+		*p.Cards(z) = zc.cards
+	}
+}
+
+func (mc *MoveCommand) Execute() {
+	g := mc.Game
+	mc.fromPlayer, mc.toPlayer = mc.Card.Owner, mc.Card.Owner
+	if mc.From == BattlefieldZone {
+		mc.fromPlayer = g.Controller(mc.Permanent)
+	}
+	if mc.To == BattlefieldZone || mc.To == StackZone {
+		mc.toPlayer = mc.Player
+	}
+	mc.from = mc.save(mc.fromPlayer, mc.From)
+	switch mc.From {
+	case BattlefieldZone:
+		bf := mc.fromPlayer.BattleField
+		i := indexOf(bf, mc.Permanent)
+		if i < 0 {
+			panic(mc.Card.Name + " is not on the battlefield")
+		}
+		if mc.To == GraveyardZone {
+			ch := g.Characteristics(mc.Permanent)
+			mc.dies = ch.Is(Creature)
+		}
+		mc.fromPlayer.BattleField = without(bf, i)
+		mc.Permanent.Controller = nil
+		if mc.Card.Token {
+			return
+		}
+	case StackZone:
+		i := len(g.Stack) - 1
+		for i >= 0 && !(g.Stack[i].Card == mc.Card && g.Stack[i].IsSpell()) {
+			i--
+		}
+		if i < 0 {
+			panic(mc.Card.Name + " is not on the stack")
+		}
+		g.Stack = without(g.Stack, i)
+	default:
+		z := mc.fromPlayer.Cards(mc.From)
+		i := indexOf(*z, mc.Card)
+		if i < 0 {
+			panic(fmt.Sprintf("%s is not in %v", mc.Card.Name, mc.From))
+		}
+		*z = without(*z, i)
+	}
+	p := mc.toPlayer
+	mc.to = mc.save(p, mc.To)
+	switch mc.To {
+	case BattlefieldZone:
+		mc.timestamp = g.Enter(p, mc.Entering)
+	case StackZone:
+		g.Stack = append(g.Stack[:len(g.Stack):len(g.Stack)], &StackObject{
+			Controller: p,
+			Card:       mc.Card,
+			Effect:     mc.Card.Spell,
+		})
+	default:
+		z := p.Cards(mc.To)
+		*z = insert(*z, mc.Position, mc.Card)
+	}
+}
+
+func (mc *MoveCommand) Events() []Event {
+	var es []Event
+	switch {
+	case mc.dies:
+		es = append(es, Event{Kind: Dies, Player: mc.fromPlayer, Permanent: mc.Permanent})
+	case mc.From == BattlefieldZone && mc.To == ExileZone:
+		es = append(es, Event{Kind: Exiled, Player: mc.fromPlayer, Permanent: mc.Permanent})
+	}
+	if mc.To == BattlefieldZone {
+		es = append(es, Event{Kind: EntersBattlefield, Player: mc.Player, Permanent: mc.Entering})
+	}
+	return es
+}
+
+func (mc *MoveCommand) Undo() {
+	if !(mc.From == BattlefieldZone && mc.Card.Token) {
+		if mc.To == BattlefieldZone {
+			mc.Game.Leave(mc.toPlayer, mc.Entering, mc.timestamp)
+		} else {
+			mc.restore(mc.toPlayer, mc.To, mc.to)
+		}
+	}
+	mc.restore(mc.fromPlayer, mc.From, mc.from)
+	if mc.From == BattlefieldZone {
+		mc.Permanent.Controller = mc.fromPlayer
+	}
+}
+
+// insert returns cs with c inserted at i, or at the end when i is negative or
+// past it, leaving the array of cs untouched so that cs can be restored on
+// undo.
+func insert(cs []*Object, i int, c *Object) []*Object {
+	if i < 0 || i > len(cs) {
+		i = len(cs)
+	}
+	r := make([]*Object, 0, len(cs)+1)
+	r = append(r, cs[:i]...)
+	r = append(r, c)
+	return append(r, cs[i:]...)
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveCommand(t *testing.T) {
+	p := &Player{}
+	g := &Game{Players: []*Player{p}}
+	a, b, c := g.NewObject(&Card{Name: "A"}, p), g.NewObject(&Card{Name: "B"}, p), g.NewObject(&Card{Name: "C"}, p)
+	bear := g.NewObject(&Card{Name: "Bear", Type: Creature, Toughness: 2}, p)
+	token := g.NewObject(&Card{Name: "Token", Type: Creature, Toughness: 1, Token: true}, p)
+	p.Library, p.Hand = []*Object{a, b}, []*Object{c}
+	perm := &Permanent{Controller: p, Type: Creature, Card: bear, Tapped: true, Damage: 1}
+	tokenPerm := &Permanent{Controller: p, Type: Creature, Card: token}
+	p.BattleField = []*Permanent{perm, tokenPerm}
+	var events []EventKind
+	g.Listen(func(g *Game, e *Event) { events = append(events, e.Kind) })
+	move := func(card *Object, perm *Permanent, from, to Zone, pos int) *MoveCommand {
+		mc := &MoveCommand{Game: g, Player: p, Card: card, Permanent: perm, From: from, To: to, Position: pos}
+		g.Execute(mc)
+		return mc
+	}
+
+	cp := g.Checkpoint()
+	move(c, nil, HandZone, LibraryZone, 1)
+	if !reflect.DeepEqual(p.Library, []*Object{a, c, b}) || len(p.Hand) != 0 {
+		t.Errorf("library %v and hand %v", p.Library, p.Hand)
+	}
+	move(a, nil, LibraryZone, LibraryZone, Bottom)
+	if !reflect.DeepEqual(p.Library, []*Object{c, b, a}) {
+		t.Errorf("library %v after putting the top card on the bottom", p.Library)
+	}
+	move(bear, perm, BattlefieldZone, GraveyardZone, Bottom)
+	move(token, tokenPerm, BattlefieldZone, ExileZone, Bottom)
+	if len(p.BattleField) != 0 || !reflect.DeepEqual(p.GraveYard, []*Object{bear}) || len(p.Exile) != 0 {
+		t.Errorf("battlefield %v, graveyard %v and exile %v", p.BattleField, p.GraveYard, p.Exile)
+	}
+	// The bear returns as a new permanent, keeping its object.
+	back := g.Entering(p, bear)
+	g.Execute(&MoveCommand{Game: g, Player: p, Card: bear, Entering: back, From: GraveyardZone, To: BattlefieldZone})
+	if back == perm || back.Tapped || back.Damage != 0 || !back.SummoningSick || !reflect.DeepEqual(p.BattleField, []*Permanent{back}) {
+		t.Errorf("bear returned as %+v", back)
+	}
+	if tapland := g.NewObject(&Card{Name: "Tapland", Type: Land, EntersTapped: true}, p); !g.Entering(p, tapland).Tapped {
+		t.Errorf("tapland would enter untapped")
+	}
+	move(b, nil, LibraryZone, StackZone, Top)
+	if len(g.Stack) != 1 || g.Stack[0].Card != b || g.Stack[0].Controller != p {
+		t.Errorf("stack %v", g.Stack)
+	}
+	move(b, nil, StackZone, CommandZone, Bottom)
+	if len(g.Stack) != 0 || !reflect.DeepEqual(p.Command, []*Object{b}) {
+		t.Errorf("stack %v and command zone %v", g.Stack, p.Command)
+	}
+	want := []EventKind{Dies, Exiled, EntersBattlefield}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events %v, want %v", events, want)
+	}
+
+	g.Rollback(cp)
+	if !reflect.DeepEqual(p.Library, []*Object{a, b}) || !reflect.DeepEqual(p.Hand, []*Object{c}) ||
+		!reflect.DeepEqual(p.BattleField, []*Permanent{perm, tokenPerm}) ||
+		len(p.GraveYard)+len(p.Exile)+len(p.Command)+len(g.Stack) != 0 {
+		t.Errorf("after rollback library %v, hand %v, battlefield %v, graveyard %v, exile %v, command zone %v, stack %v",
+			p.Library, p.Hand, p.BattleField, p.GraveYard, p.Exile, p.Command, g.Stack)
+	}
+	g.Redo(Checkpoint(7))
+	if !reflect.DeepEqual(p.BattleField, []*Permanent{back}) || !reflect.DeepEqual(p.Command, []*Object{b}) {
+		t.Errorf("after redo battlefield %v and command zone %v", p.BattleField, p.Command)
+	}
+
+	// Moving a card that is not in From is a bug.
+	defer func() {
+		if recover() == nil {
+			t.Errorf("moved a card that is not in hand")
+		}
+	}()
+	move(a, nil, HandZone, GraveyardZone, Bottom)
+}
@@ -0,0 +1,52 @@
+package model
+
+import "math/rand"
+
+// OpeningHandSize is the number of cards a player draws before the game.
+const OpeningHandSize = 7
+
+// DrawOpeningHands shuffles the libraries and has each player draw their
+// opening hand, following the London mulligan: a player who mulligans
+// shuffles their hand back and draws a new one, and once they keep, puts a
+// card on the bottom of their library for each mulligan taken. This happens
+// before the game starts and is not recorded in the journal. It returns an
+// error when an agent chooses cards illegally.
+func (g *Game) DrawOpeningHands(r *rand.Rand) error {
+	for i := range g.Players {
+		p := g.Players[(g.ActivePlayer+i)%len(g.Players)]
+		a := p.agent()
+		shuffle(r, p.Library)
+		p.drawHand()
+		mulligans := 0
+		for mulligans < OpeningHandSize && a.Mulligan(g, p) {
+			p.Library = append(p.Library, p.Hand...)
+			p.Hand = nil
+			shuffle(r, p.Library)
+			p.drawHand()
+			mulligans++
+		}
+		if mulligans == 0 {
+			continue
+		}
+		bottom := g.chooseCards(p, "bottom", mulligans)
+		var hand []*Object
+		for _, c := range p.Hand {
+			if indexOf(bottom, c) < 0 {
+				hand = append(hand, c)
+			}
+		}
+		p.Hand = hand
+		p.Library = append(p.Library, bottom...)
+	}
+	return g.rejection()
+}
+
+func (p *Player) drawHand() {
+	n := min(OpeningHandSize, len(p.Library))
+	p.Hand = append(p.Hand, p.Library[:n]...)
+	p.Library = p.Library[n:]
+}
+
+func shuffle(r *rand.Rand, cs []*Object) {
+	r.Shuffle(len(cs), func(i, j int) { cs[i], cs[j] = cs[j], cs[i] })
+}
@@ -0,0 +1,107 @@
+package model
+
+import "sort"
+
+// ReplacementEffect is a static ability that modifies events before they
+// happen, e.g. "If a creature would die, exile it instead".
+type ReplacementEffect interface {
+	// Replaces reports whether the effect applies to e. c holds the
+	// permanent with the ability and its controller.
+	Replaces(e *Event, c *Context) bool
+	Replace(e *Event, c *Context)
+}
+
+// Replacement is a replacement effect of a permanent on the battlefield.
+type Replacement struct {
+	ReplacementEffect
+	Context *Context
+}
+
+// Replace applies the replacement effects to e, which is about to happen.
+// The card's own "enters tapped" applies first. Then, while several effects
+// apply, the affected player chooses which one does next, the options being
+// Replacements in timestamp order. Each effect applies
+// at most once, and effects are checked again after each one, since it may
+// have changed the event.
+func (g *Game) Replace(e *Event) {
+	if e.Kind == EntersBattlefield && e.Permanent.Card.EntersTapped {
+		e.Tapped = true
+	}
+	if !g.mayReplace() {
+		return
+	}
+	// Only a copy of e escapes to the heap, so that callers' events stay on
+	// the stack when nothing may replace them.
+	ec := *e
+	g.replace(&ec)
+	*e = ec
+}
+
+// mayReplace reports whether a permanent on the battlefield has replacement
+// effects.
+func (g *Game) mayReplace() bool {
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			if len(perm.Card.ReplacementEffects) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Game) replace(e *Event) {
+	var used []Replacement
+	for !e.Cancelled {
+		var rs []Replacement
+		for _, p := range g.Players {
+			for _, perm := range p.BattleField {
+				for _, re := range perm.Card.ReplacementEffects {
+					r := Replacement{re, &Context{Game: g, Player: p, Permanent: perm}}
+					if !applied(used, r) && re.Replaces(e, r.Context) {
+						rs = append(rs, r)
+					}
+				}
+			}
+		}
+		if len(rs) == 0 {
+			return
+		}
+		sort.SliceStable(rs, func(i, j int) bool {
+			return rs[i].Context.Permanent.Timestamp < rs[j].Context.Permanent.Timestamp
+		})
+		i := 0
+		if p := g.affected(e); p != nil {
+			options := make([]any, len(rs))
+			for j, r := range rs {
+				options[j] = r
+			}
+			i = g.Choose(p, "replacement", options)
+		}
+		rs[i].Replace(e, rs[i].Context)
+		used = append(used, rs[i])
+	}
+}
+
+// affected returns the player affected by e, who chooses among the
+// replacement effects applying to it (rule 616.1): the player dealt damage or
+// the controller of the permanent dealt damage, otherwise Player.
+func (g *Game) affected(e *Event) *Player {
+	switch e.Kind {
+	case DealsDamage, DealsCombatDamage:
+		if e.TargetPermanent != nil {
+			return g.Controller(e.TargetPermanent)
+		}
+		return e.Target
+	}
+	return e.Player
+}
+
+func applied(used []Replacement, r Replacement) bool {
+	for _, u := range used {
+		if u.ReplacementEffect == r.ReplacementEffect && u.Context.Permanent == r.Context.Permanent {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package model
+
+import "testing"
+
+// addDamage adds N to the damage dealt to its controller.
+type addDamage struct {
+	N int
+}
+
+func (ad *addDamage) Replaces(e *Event, c *Context) bool {
+	return e.Kind == DealsDamage && e.Target == c.Player
+}
+
+func (ad *addDamage) Replace(e *Event, c *Context) { e.Amount += ad.N }
+
+// halveDamage halves the damage dealt to its controller, rounding down.
+type halveDamage struct{}
+
+func (hd *halveDamage) Replaces(e *Event, c *Context) bool {
+	return e.Kind == DealsDamage && e.Target == c.Player
+}
+
+func (hd *halveDamage) Replace(e *Event, c *Context) { e.Amount /= 2 }
+
+// skipDraws replaces the draws of its controller with nothing.
+type skipDraws struct{}
+
+func (sd *skipDraws) Replaces(e *Event, c *Context) bool {
+	return e.Kind == Draws && e.Player == c.Player
+}
+
+func (sd *skipDraws) Replace(e *Event, c *Context) { e.Cancelled = true }
+
+func TestReplace(t *testing.T) {
+	p0, p1 := &Player{}, &Player{}
+	g := &Game{Players: []*Player{p0, p1}}
+	enter := func(c *Object) {
+		p0.BattleField = append(p0.BattleField, &Permanent{Controller: p0, Type: c.Type, Card: c, Timestamp: g.NextTimestamp()})
+	}
+	enter(obj(&Card{Name: "Add", ReplacementEffects: []ReplacementEffect{&addDamage{N: 2}}}))
+	enter(obj(&Card{Name: "Halve", ReplacementEffects: []ReplacementEffect{&halveDamage{}}}))
+
+	// Each effect applies once, in timestamp order unless the affected player
+	// chooses.
+	e := Event{Kind: DealsDamage, Player: p1, Target: p0, Amount: 4}
+	g.Replace(&e)
+	if e.Amount != 3 {
+		t.Errorf("damage %d in timestamp order, want 3", e.Amount)
+	}
+	// The player dealt damage chooses, not the source's controller.
+	p0.Agent = chooseLastOption{}
+	e = Event{Kind: DealsDamage, Player: p1, Target: p0, Amount: 4}
+	g.Replace(&e)
+	if e.Amount != 4 {
+		t.Errorf("damage %d halved first, want 4", e.Amount)
+	}
+
+	// A land entering tapped has its own replacement effect.
+	tapland := obj(&Card{Name: "Tapland", Type: Land, EntersTapped: true})
+	e = Event{Kind: EntersBattlefield, Player: p1, Permanent: &Permanent{Card: tapland}}
+	g.Replace(&e)
+	if !e.Tapped {
+		t.Errorf("tapland entered untapped")
+	}
+}
+
+func TestSkipDraw(t *testing.T) {
+	p0 := &Player{First: true, Library: cards(3)}
+	p1 := &Player{Library: cards(3)}
+	p1.BattleField = []*Permanent{{Controller: p1, Card: obj(&Card{Name: "Skip", ReplacementEffects: []ReplacementEffect{&skipDraws{}}})}}
+	g := &Game{Players: []*Player{p0, p1}}
+	var draws []*Player
+	g.Listen(func(g *Game, e *Event) {
+		if e.Kind == Draws {
+			draws = append(draws, e.Player)
+		}
+	})
+
+	g.Draw(p0)
+	g.Draw(p1)
+	if len(p0.Hand) != 1 || len(p1.Hand) != 0 || len(p1.Library) != 3 {
+		t.Errorf("hands %d and %d, want 1 and 0", len(p0.Hand), len(p1.Hand))
+	}
+	if len(draws) != 1 || draws[0] != p0 {
+		t.Errorf("draw events for %v, want p0 only", draws)
+	}
+}
@@ -0,0 +1,192 @@
+package model
+
+// Over reports whether the game is over: at most one player has not lost.
+func (g *Game) Over() bool {
+	n := 0
+	for _, p := range g.Players {
+		if !p.Lost {
+			n++
+		}
+	}
+	return n <= 1
+}
+
+// CheckStateBasedActions performs state-based actions until there are none
+// left to perform, and reports whether it performed any.
+func (g *Game) CheckStateBasedActions() bool {
+	performed := false
+	for g.stateBasedActions() {
+		performed = true
+	}
+	return performed
+}
+
+// stateBasedActions performs the state-based actions that apply to the game
+// as it is, and reports whether there were any:
+//   - a player with 0 or less life, who drew from an empty library, or with
+//     ten or more poison counters loses;
+//   - a creature with 0 or less toughness dies, as does one with damage at
+//     least its toughness or from a source with deathtouch, unless it is
+//     indestructible;
+//   - a planeswalker with no loyalty counters is put into its owner's
+//     graveyard;
+//   - of legendary permanents with the same name controlled by one player,
+//     the player chooses one and the others die;
+//   - a permanent with both +1/+1 and -1/-1 counters loses as many of each
+//     as it has of the fewer.
+func (g *Game) stateBasedActions() bool {
+	var lose []*Player
+	var dying []*Permanent
+	var annihilate []*annihilateCommand
+	for _, p := range g.Players {
+		if p.Lost {
+			continue
+		}
+		if p.Life <= 0 || p.DrewFromEmptyLibrary || p.Counters[PoisonCounter] >= PoisonLimit {
+			lose = append(lose, p)
+		}
+		var legends map[string][]*Permanent
+		var names []string
+		for _, perm := range p.BattleField {
+			if n := min(perm.Counters[PlusOneCounter], perm.Counters[MinusOneCounter]); n > 0 {
+				annihilate = append(annihilate, &annihilateCommand{Permanent: perm, N: n})
+			}
+			ch := g.Characteristics(perm)
+			if ch.Is(Creature) && (ch.Toughness <= 0 ||
+				!ch.HasKeyword(Indestructible) && (perm.Damage >= ch.Toughness || perm.Deathtouched)) ||
+				ch.Is(Planeswalker) && perm.Counters[LoyaltyCounter] <= 0 {
+				dying = append(dying, perm)
+				continue
+			}
+			if !perm.Card.Legendary {
+				continue
+			}
+			if legends == nil {
+				legends = make(map[string][]*Permanent)
+			}
+			if legends[perm.Card.Name] == nil {
+				names = append(names, perm.Card.Name)
+			}
+			legends[perm.Card.Name] = append(legends[perm.Card.Name], perm)
+		}
+		for _, name := range names {
+			perms := legends[name]
+			if len(perms) < 2 {
+				continue
+			}
+			options := make([]any, len(perms))
+			for i, perm := range perms {
+				options[i] = perm
+			}
+			dying = append(dying, without(perms, g.Choose(p, "legend rule", options))...)
+		}
+	}
+	for _, p := range lose {
+		g.Execute(&loseCommand{Player: p})
+	}
+	for _, perm := range dying {
+		g.Execute(g.PutIntoGraveyard(perm))
+	}
+	for _, ac := range annihilate {
+		if g.Controller(ac.Permanent) != nil {
+			g.Execute(ac)
+		}
+	}
+	return len(lose) > 0 || len(dying) > 0 || len(annihilate) > 0
+}
+
+// PutIntoGraveyard returns the command putting perm into its owner's
+// graveyard from the battlefield. Only a creature dies, which a replacement
+// effect may exile instead.
+func (g *Game) PutIntoGraveyard(perm *Permanent) Command {
+	mc := &MoveCommand{Game: g, Card: perm.Card, Permanent: perm, From: BattlefieldZone, To: GraveyardZone, Position: Bottom}
+	if ch := g.Characteristics(perm); ch.Is(Creature) {
+		e := Event{Kind: Dies, Player: g.Controller(perm), Permanent: perm}
+		g.Replace(&e)
+		if e.Kind == Exiled {
+			mc.To = ExileZone
+		}
+	}
+	return mc
+}
+
+// beforePriority performs state-based actions and puts triggered abilities on
+// the stack, repeating until neither happens, and reports whether any ability
+// was put on the stack.
+func (g *Game) beforePriority() bool {
+	triggered := false
+	for {
+		g.CheckStateBasedActions()
+		if len(g.Triggered) == 0 {
+			return triggered
+		}
+		g.PutTriggersOnStack()
+		triggered = true
+	}
+}
+
+// loseCommand implements Command.
+var _ Command = (*loseCommand)(nil)
+
+type loseCommand struct {
+	Player *Player
+}
+
+func (lc *loseCommand) Execute() {
+	lc.Player.Lost = true
+}
+
+func (lc *loseCommand) Undo() {
+	lc.Player.Lost = false
+}
+
+// endEffectsCommand implements Command.
+var _ Command = (*endEffectsCommand)(nil)
+
+// endEffectsCommand ends the effects lasting until end of turn.
+type endEffectsCommand struct {
+	Game *Game
+
+	prev []*ContinuousEffect
+}
+
+func (ec *endEffectsCommand) Execute() {
+	ec.prev = ec.Game.UntilEndOfTurn
+	ec.Game.UntilEndOfTurn = nil
+}
+
+func (ec *endEffectsCommand) Undo() {
+	ec.Game.UntilEndOfTurn = ec.prev
+}
+
+// clearDamageCommand implements Command.
+var _ Command = (*clearDamageCommand)(nil)
+
+// clearDamageCommand removes the damage marked on all permanents.
+type clearDamageCommand struct {
+	Game *Game
+
+	damaged      []*Permanent
+	damage       []int
+	deathtouched []bool
+}
+
+func (cc *clearDamageCommand) Execute() {
+	cc.damaged, cc.damage, cc.deathtouched = cc.damaged[:0], cc.damage[:0], cc.deathtouched[:0]
+	for _, p := range cc.Game.Players {
+		for _, perm := range p.BattleField {
+			if perm.Damage > 0 || perm.Deathtouched {
+				cc.damaged = append(cc.damaged, perm)
+				cc.damage = append(cc.damage, perm.Damage)
+				cc.deathtouched = append(cc.deathtouched, perm.Deathtouched)
+				perm.Damage, perm.Deathtouched = 0, false
+			}
+		}
+	}
+}
+
+func (cc *clearDamageCommand) Undo() {
+	for i, perm := range cc.damaged {
+		perm.Damage, perm.Deathtouched = cc.damage[i], cc.deathtouched[i]
+	}
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// exileDying exiles the creatures that would die.
+type exileDying struct{}
+
+func (ed *exileDying) Replaces(e *Event, c *Context) bool { return e.Kind == Dies }
+func (ed *exileDying) Replace(e *Event, c *Context)       { e.Kind = Exiled }
+
+func TestStateBasedActions(t *testing.T) {
+	// p0 keeps the newest legend.
+	p0 := &Player{Life: 20, Agent: chooseLastOption{}}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}}
+	bear := g.NewObject(&Card{Name: "Bear", Type: Creature, Power: 2, Toughness: 2}, p0)
+	wall := g.NewObject(&Card{Name: "Wall", Type: Creature, Toughness: 0}, p0)
+	token := g.NewObject(&Card{Name: "Token", Type: Creature, Toughness: 1, Token: true}, p0)
+	legend := g.NewObject(&Card{Name: "Legend", Type: Creature, Toughness: 1, Legendary: true}, p0)
+	walker := g.NewObject(&Card{Name: "Walker", Type: Planeswalker}, p0)
+	enter := func(p *Player, c *Object) *Permanent {
+		perm := &Permanent{Controller: p, Type: c.Type, Card: c, Timestamp: g.NextTimestamp()}
+		p.BattleField = append(p.BattleField, perm)
+		return perm
+	}
+	var died []*Object
+	g.Listen(func(g *Game, e *Event) {
+		if e.Kind == Dies {
+			died = append(died, e.Permanent.Card)
+		}
+	})
+
+	healthy := enter(p0, bear)
+	enter(p0, bear).Damage = 2
+	enter(p0, wall)
+	enter(p0, token).Damage = 1
+	enter(p0, walker)
+	enter(p0, legend)
+	newest := enter(p0, legend)
+	other := enter(p1, legend)
+
+	if g.CheckStateBasedActions(); g.Over() {
+		t.Fatalf("game is over")
+	}
+	if !reflect.DeepEqual(p0.BattleField, []*Permanent{healthy, newest}) || !reflect.DeepEqual(p1.BattleField, []*Permanent{other}) {
+		t.Errorf("battlefields %v and %v", p0.BattleField, p1.BattleField)
+	}
+	// The token ceases to exist, and the planeswalker does not die.
+	if !reflect.DeepEqual(p0.GraveYard, []*Object{bear, wall, walker, legend}) {
+		t.Errorf("graveyard %v", p0.GraveYard)
+	}
+	if len(died) != 4 {
+		t.Errorf("%d creatures died, want 4", len(died))
+	}
+	if g.CheckStateBasedActions() {
+		t.Errorf("state-based actions performed twice")
+	}
+
+	p1.BattleField[0].Card.Legendary = false
+	p1.BattleField = append(p1.BattleField, &Permanent{Controller: p1, Card: obj(&Card{
+		Name:               "Exiler",
+		ReplacementEffects: []ReplacementEffect{&exileDying{}},
+	})})
+	healthy.Damage = 5
+	p1.Life = 0
+	g.CheckStateBasedActions()
+	if !reflect.DeepEqual(p0.Exile, []*Object{bear}) || len(died) != 4 {
+		t.Errorf("exile %v after %d deaths", p0.Exile, len(died))
+	}
+	if p0.Lost || !p1.Lost || !g.Over() {
+		t.Errorf("lost %t and %t, want p1 to lose", p0.Lost, p1.Lost)
+	}
+}
+
+func TestIndestructible(t *testing.T) {
+	p := &Player{Life: 20}
+	g := &Game{Players: []*Player{p}}
+	god := obj(&Card{Name: "God", Type: Creature, Toughness: 4, Keywords: []Keyword{Indestructible}})
+	damaged := &Permanent{Controller: p, Type: Creature, Card: god, Damage: 5}
+	deathtouched := &Permanent{Controller: p, Type: Creature, Card: god, Damage: 1, Deathtouched: true}
+	shrunk := &Permanent{Controller: p, Type: Creature, Card: g.NewObject(&Card{Name: "Shrunk", Type: Creature, Keywords: []Keyword{Indestructible}}, p)}
+	p.BattleField = []*Permanent{damaged, deathtouched, shrunk}
+	g.CheckStateBasedActions()
+	// Indestructible creatures survive damage, but not 0 toughness.
+	if !reflect.DeepEqual(p.BattleField, []*Permanent{damaged, deathtouched}) {
+		t.Errorf("battlefield %v", p.BattleField)
+	}
+}
+
+func TestDrawFromEmptyLibrary(t *testing.T) {
+	p0 := &Player{First: true, Life: 20}
+	p1 := &Player{Life: 20, Library: cards(1)}
+	g := &Game{Players: []*Player{p0, p1}}
+	g.Start()
+	for p1.Turn < 2 && !g.Over() {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// p0 skips their first draw, then loses on the second.
+	if !p0.Lost || p1.Lost || p0.Turn != 2 || g.CurrentPart != DrawStep {
+		t.Errorf("lost %t and %t in turn %d, %v", p0.Lost, p1.Lost, p0.Turn, g.CurrentPart)
+	}
+}
+
+func TestPutIntoGraveyard(t *testing.T) {
+	p, owner := &Player{}, &Player{}
+	g := &Game{Players: []*Player{p, owner}}
+	a, b := g.NewObject(&Card{Name: "A"}, p), g.NewObject(&Card{Name: "B"}, owner)
+	pa, pb := &Permanent{Controller: p, Card: a}, &Permanent{Controller: p, Card: b}
+	p.BattleField = []*Permanent{pa, pb}
+	c := g.PutIntoGraveyard(pa)
+	c.Execute()
+	if !reflect.DeepEqual(p.BattleField, []*Permanent{pb}) || !reflect.DeepEqual(p.GraveYard, []*Object{a}) {
+		t.Fatalf("battlefield %v and graveyard %v", p.BattleField, p.GraveYard)
+	}
+	c.Undo()
+	if !reflect.DeepEqual(p.BattleField, []*Permanent{pa, pb}) || len(p.GraveYard) != 0 {
+		t.Errorf("battlefield %v and graveyard %v after undo", p.BattleField, p.GraveYard)
+	}
+
+	// A permanent goes to its owner's graveyard, not its controller's.
+	g.PutIntoGraveyard(pb).Execute()
+	if !reflect.DeepEqual(owner.GraveYard, []*Object{b}) || len(p.GraveYard) != 0 {
+		t.Errorf("graveyards %v and %v, want the owner's", owner.GraveYard, p.GraveYard)
+	}
+}
@@ -0,0 +1,482 @@
+package model
+
+import "fmt"
+
+// StackObject is a spell or an activated ability on the stack.
+type StackObject struct {
+	Controller *Player
+	// Card is the spell, or the card of the permanent whose ability this is.
+	Card *Object
+	// Source is the permanent whose ability this is, nil for a spell.
+	Source *Permanent
+	// Effect is what happens on resolution. Permanent spells have none.
+	Effect  Effect
+	Targets []Target
+	// Modes are the modes chosen for a modal spell or ability, see Modal.
+	Modes []int
+}
+
+func (so *StackObject) IsSpell() bool {
+	return so.Source == nil
+}
+
+// Action is what a player does with priority. The zero Action passes.
+type Action struct {
+	// Card is a card in hand to play: lands are played, other cards cast.
+	Card *Object
+	// Ability is an ability of Permanent to activate.
+	Ability   ActivatedAbility
+	Permanent *Permanent
+	// Targets are the targets chosen for the spell or ability.
+	Targets []Target
+	// Modes are the modes chosen for a modal spell.
+	Modes []int
+}
+
+func (a Action) IsPass() bool {
+	return a.Card == nil && a.Ability == nil
+}
+
+// GivesPriority reports whether players receive priority during p. The
+// beginning and combat phases only have priority in their steps.
+func (p Part) GivesPriority() bool {
+	switch p {
+	case BeginningPhase, UntapStep, CombatPhase, CleanupStep:
+		return false
+	}
+	return true
+}
+
+func (c *Card) IsPermanent() bool {
+	switch c.Type {
+	case Artifact, Creature, Enchantment, Land, Planeswalker:
+		return true
+	}
+	return false
+}
+
+// Step advances to the next part of the turn and runs priority in it, if
+// players receive priority there. It returns an error when an agent makes an
+// illegal choice.
+func (g *Game) Step() error {
+	g.Advance()
+	if err := g.rejection(); err != nil {
+		return err
+	}
+	if g.CurrentPart.GivesPriority() {
+		return g.RunPriority()
+	}
+	return nil
+}
+
+// RunPriority gives players priority, starting with the active player, until
+// all of them pass in succession with an empty stack. A player who acts
+// receives priority again. When all players pass with a nonempty stack, the
+// top object resolves and the active player receives priority. Before anyone
+// receives priority, state-based actions are performed and triggered
+// abilities put on the stack; priority ends when that ends the game. It
+// returns an error when an agent takes an illegal action or makes an illegal
+// choice.
+func (g *Game) RunPriority() error {
+	for {
+		i, passes := g.ActivePlayer, 0
+		for passes < len(g.Players) {
+			if g.beforePriority() {
+				passes = 0
+			}
+			if err := g.rejection(); err != nil {
+				return err
+			}
+			if g.Over() {
+				return nil
+			}
+			p := g.Players[i]
+			a := p.agent().Priority(g, p)
+			if a.IsPass() {
+				passes++
+				i = (i + 1) % len(g.Players)
+				continue
+			}
+			if err := g.Perform(p, a); err != nil {
+				return err
+			}
+			passes = 0
+		}
+		if len(g.Stack) == 0 {
+			return nil
+		}
+		g.Resolve()
+	}
+}
+
+// Perform carries out an action of p, who has priority.
+func (g *Game) Perform(p *Player, a Action) error {
+	switch {
+	case a.Ability != nil:
+		return g.Activate(p, a.Permanent, a.Ability, a.Targets...)
+	case a.Card.Type == Land:
+		return g.PlayLand(p, a.Card)
+	default:
+		return g.CastModes(p, a.Card, a.Modes, a.Targets...)
+	}
+}
+
+// LegalActions returns the actions other than passing that p, who has
+// priority, may take: lands to play, spells to cast with the mana in their
+// pool and abilities whose costs they can pay, each with every combination of
+// modes and legal targets.
+func (g *Game) LegalActions(p *Player) []Action {
+	var as []Action
+	c := &Context{Game: g, Player: p}
+	for _, card := range p.Hand {
+		switch {
+		case card.Type == Land:
+			if g.SorcerySpeed(p) && p.LandsPlayed == 0 {
+				as = append(as, Action{Card: card})
+			}
+		case card.Type != Instant && !g.SorcerySpeed(p):
+		default:
+			if _, ok := card.Cost.Pay(p.ManaPool); !ok {
+				continue
+			}
+			m := modalOf(card.Spell)
+			if m == nil {
+				for _, ts := range g.targetCombinations(c, targetSpecs(card.Spell)) {
+					as = append(as, Action{Card: card, Targets: ts})
+				}
+				continue
+			}
+			for _, modes := range m.combinations() {
+				for _, ts := range g.targetCombinations(c, modeSpecs(m, modes)) {
+					as = append(as, Action{Card: card, Targets: ts, Modes: modes})
+				}
+			}
+		}
+	}
+	for _, perm := range p.BattleField {
+		c := &Context{Game: g, Player: p, Permanent: perm}
+		for _, aa := range perm.Card.ActivatedAbilities {
+			if !canActivate(c, aa) {
+				continue
+			}
+			for _, ts := range g.targetCombinations(c, targetSpecs(aa)) {
+				c.Targets = ts
+				if cost := aa.Cost(); cost == nil || cost.CanPay(c) {
+					as = append(as, Action{Ability: aa, Permanent: perm, Targets: ts})
+				}
+			}
+			c.Targets = nil
+		}
+	}
+	return as
+}
+
+// SorcerySpeed reports whether p may now do what is limited to the timing of
+// a sorcery: in their own main phase, with an empty stack.
+func (g *Game) SorcerySpeed(p *Player) bool {
+	return g.Active() == p && len(g.Stack) == 0 &&
+		(g.CurrentPart == FirstMainPhase || g.CurrentPart == SecondMainPhase)
+}
+
+func indexOf[T comparable](s []T, e T) int {
+	for i, se := range s {
+		if se == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Game) PlayLand(p *Player, c *Object) error {
+	i := indexOf(p.Hand, c)
+	switch {
+	case i < 0:
+		return fmt.Errorf("%s is not in hand", c.Name)
+	case !g.SorcerySpeed(p):
+		return fmt.Errorf("cannot play %s now", c.Name)
+	case p.LandsPlayed > 0:
+		return fmt.Errorf("cannot play %s: already played a land this turn", c.Name)
+	}
+	g.Execute(&playLandCommand{Game: g, Player: p, Index: i, Permanent: g.Entering(p, c)})
+	return nil
+}
+
+// Entering returns the new permanent c becomes as it enters the battlefield
+// under p's control, untapped and summoning sick unless the replacement
+// effects applying to its entering, such as entering tapped, say otherwise.
+// Callers get it before executing the command putting it onto the
+// battlefield, so that redoing the command asks for no choices. The command
+// gives it its timestamp when executed, see Enter.
+func (g *Game) Entering(p *Player, c *Object) *Permanent {
+	perm := &Permanent{
+		Type:          c.Type,
+		Card:          c,
+		SummoningSick: true,
+		Counters:      c.EntersWith(),
+	}
+	e := Event{Kind: EntersBattlefield, Player: p, Permanent: perm}
+	g.Replace(&e)
+	perm.Tapped = e.Tapped
+	return perm
+}
+
+// Enter puts perm, which is entering the battlefield, onto p's battlefield
+// under p's control with the next timestamp. It is for commands to call on
+// Execute, and returns the timestamp they pass to Leave on Undo.
+func (g *Game) Enter(p *Player, perm *Permanent) (prev int) {
+	prev = g.Timestamp
+	p.BattleField = append(p.BattleField, perm)
+	perm.Controller = p
+	perm.Timestamp = g.NextTimestamp()
+	return prev
+}
+
+// Leave undoes Enter, which put perm onto p's battlefield when the timestamp
+// was prev.
+func (g *Game) Leave(p *Player, perm *Permanent, prev int) {
+	perm.Controller = nil
+	p.BattleField = p.BattleField[:len(p.BattleField)-1]
+	g.Timestamp = prev
+}
+
+// Cast casts c from p's hand with targets, paying its mana cost from p's mana
+// pool.
+func (g *Game) Cast(p *Player, c *Object, targets ...Target) error {
+	return g.CastModes(p, c, nil, targets...)
+}
+
+// CastModes casts c like Cast, choosing modes of a modal spell. targets are
+// those of each mode in turn.
+func (g *Game) CastModes(p *Player, c *Object, modes []int, targets ...Target) error {
+	i := indexOf(p.Hand, c)
+	switch {
+	case i < 0:
+		return fmt.Errorf("%s is not in hand", c.Name)
+	case c.Type != Instant && !g.SorcerySpeed(p):
+		return fmt.Errorf("cannot cast %s now", c.Name)
+	}
+	if m := modalOf(c.Spell); m != nil {
+		if err := m.check(modes); err != nil {
+			return fmt.Errorf("cannot cast %s: %v", c.Name, err)
+		}
+	} else if len(modes) > 0 {
+		return fmt.Errorf("cannot cast %s: it is not modal", c.Name)
+	}
+	if err := g.checkTargets(&Context{Game: g, Player: p}, modeSpecs(c.Spell, modes), targets); err != nil {
+		return fmt.Errorf("cannot cast %s: %v", c.Name, err)
+	}
+	if _, ok := c.Cost.Pay(p.ManaPool); !ok {
+		return fmt.Errorf("cannot pay %s for %s from %v", c.Cost, c.Name, p.ManaPool)
+	}
+	g.Execute(&castCommand{Game: g, Player: p, Index: i, Targets: targets, Modes: modes})
+	g.Execute(&PayManaCommand{Cost: c.Cost, Player: p})
+	return nil
+}
+
+// Activate activates ability aa of perm, which p controls, paying its cost.
+// Mana abilities resolve immediately; other abilities are put on the stack
+// before the cost is paid.
+func (g *Game) Activate(p *Player, perm *Permanent, aa ActivatedAbility, targets ...Target) error {
+	switch {
+	case indexOf(p.BattleField, perm) < 0:
+		return fmt.Errorf("%s is not controlled by the player", perm.Card.Name)
+	case indexOf(perm.Card.ActivatedAbilities, aa) < 0:
+		return fmt.Errorf("%s does not have the ability", perm.Card.Name)
+	}
+	c := &Context{Game: g, Player: p, Permanent: perm}
+	if !canActivate(c, aa) {
+		return fmt.Errorf("cannot activate the ability of %s now", perm.Card.Name)
+	}
+	if err := g.checkTargets(c, targetSpecs(aa), targets); err != nil {
+		return fmt.Errorf("cannot activate the ability of %s: %v", perm.Card.Name, err)
+	}
+	c.Targets = targets
+	cost := aa.Cost()
+	if cost != nil && !cost.CanPay(c) {
+		return fmt.Errorf("cannot pay the cost of the ability of %s", perm.Card.Name)
+	}
+	if _, ok := aa.(ManaAbility); ok {
+		g.pay(cost, c)
+		for _, cmd := range aa.Commands(c) {
+			g.Execute(cmd)
+		}
+		return nil
+	}
+	g.Execute(&pushCommand{Game: g, Object: &StackObject{
+		Controller: p,
+		Card:       perm.Card,
+		Source:     perm,
+		Effect:     aa,
+		Targets:    targets,
+	}})
+	g.pay(cost, c)
+	return nil
+}
+
+// canActivate reports whether aa may be activated now as far as its
+// restrictions go.
+func canActivate(c *Context, aa ActivatedAbility) bool {
+	r, ok := aa.(Restricted)
+	return !ok || r.CanActivate(c)
+}
+
+func (g *Game) pay(cost Cost, c *Context) {
+	if cost == nil {
+		return
+	}
+	for _, cmd := range cost.Pay(c) {
+		g.Execute(cmd)
+	}
+}
+
+// Resolve resolves the top object of the stack. A spell or ability whose
+// targets have all become illegal does nothing; otherwise illegal targets are
+// left out. A spell stays on the stack as it resolves, and is then put onto
+// the battlefield or into its owner's graveyard.
+func (g *Game) Resolve() {
+	so := g.Stack[len(g.Stack)-1]
+	if !so.IsSpell() {
+		g.Execute(&popCommand{Game: g})
+	}
+	c := &Context{
+		Game:      g,
+		Player:    so.Controller,
+		Permanent: so.Source,
+		Modes:     so.Modes,
+	}
+	if so.IsSpell() {
+		c.Card = so.Card
+	}
+	if len(so.Targets) > 0 {
+		var ok bool
+		if c.Targets, ok = g.legalTargets(c, modeSpecs(so.Effect, so.Modes), so.Targets); !ok {
+			if so.IsSpell() {
+				g.Execute(&MoveCommand{Game: g, Card: so.Card, From: StackZone, To: GraveyardZone, Position: Bottom})
+			}
+			return
+		}
+	}
+	if so.Effect != nil {
+		for _, cmd := range so.Effect.Commands(c) {
+			g.Execute(cmd)
+		}
+	}
+	if !so.IsSpell() {
+		return
+	}
+	mc := &MoveCommand{Game: g, Player: so.Controller, Card: so.Card, From: StackZone, To: GraveyardZone, Position: Bottom}
+	if so.Card.IsPermanent() {
+		mc.To, mc.Entering = BattlefieldZone, g.Entering(so.Controller, so.Card)
+	}
+	g.Execute(mc)
+}
+
+// without returns s without its i-th element. Unlike append(s[:i], ...) it
+// leaves the array of s untouched, so s can be restored on undo.
+func without[T any](s []T, i int) []T {
+	return append(s[:i:i], s[i+1:]...)
+}
+
+// castCommand implements Command.
+var _ Command = (*castCommand)(nil)
+
+// castCommand moves the card at Index of Player's hand onto the stack.
+type castCommand struct {
+	Game    *Game
+	Player  *Player
+	Index   int
+	Targets []Target
+	Modes   []int
+
+	hand []*Object
+}
+
+func (cc *castCommand) Execute() {
+	cc.hand = cc.Player.Hand
+	c := cc.hand[cc.Index]
+	cc.Player.Hand = without(cc.hand, cc.Index)
+	cc.Game.Stack = append(cc.Game.Stack, &StackObject{
+		Controller: cc.Player,
+		Card:       c,
+		Effect:     c.Spell,
+		Targets:    cc.Targets,
+		Modes:      cc.Modes,
+	})
+}
+
+func (cc *castCommand) Events() []Event {
+	return []Event{{Kind: CastSpell, Player: cc.Player, Card: cc.hand[cc.Index]}}
+}
+
+func (cc *castCommand) Undo() {
+	cc.Game.Stack = cc.Game.Stack[:len(cc.Game.Stack)-1]
+	cc.Player.Hand = cc.hand
+}
+
+// playLandCommand implements Command.
+var _ Command = (*playLandCommand)(nil)
+
+// playLandCommand moves the land at Index of Player's hand onto the
+// battlefield as Permanent.
+type playLandCommand struct {
+	Game      *Game
+	Player    *Player
+	Index     int
+	Permanent *Permanent
+
+	hand      []*Object
+	timestamp int
+}
+
+func (pc *playLandCommand) Execute() {
+	p := pc.Player
+	pc.hand = p.Hand
+	p.Hand = without(pc.hand, pc.Index)
+	pc.timestamp = pc.Game.Enter(p, pc.Permanent)
+	p.LandsPlayed++
+}
+
+func (pc *playLandCommand) Events() []Event {
+	return []Event{{Kind: EntersBattlefield, Player: pc.Player, Permanent: pc.Permanent}}
+}
+
+func (pc *playLandCommand) Undo() {
+	p := pc.Player
+	p.LandsPlayed--
+	pc.Game.Leave(p, pc.Permanent, pc.timestamp)
+	p.Hand = pc.hand
+}
+
+// pushCommand implements Command.
+var _ Command = (*pushCommand)(nil)
+
+type pushCommand struct {
+	Game   *Game
+	Object *StackObject
+}
+
+func (pc *pushCommand) Execute() {
+	pc.Game.Stack = append(pc.Game.Stack, pc.Object)
+}
+
+func (pc *pushCommand) Undo() {
+	pc.Game.Stack = pc.Game.Stack[:len(pc.Game.Stack)-1]
+}
+
+// popCommand implements Command.
+var _ Command = (*popCommand)(nil)
+
+type popCommand struct {
+	Game *Game
+
+	object *StackObject
+}
+
+func (pc *popCommand) Execute() {
+	pc.object = pc.Game.Stack[len(pc.Game.Stack)-1]
+	pc.Game.Stack = pc.Game.Stack[:len(pc.Game.Stack)-1]
+}
+
+func (pc *popCommand) Undo() {
+	pc.Game.Stack = append(pc.Game.Stack, pc.object)
+}
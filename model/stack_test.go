@@ -0,0 +1,188 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// gainLife is an Effect that gains its controller N life.
+type gainLife struct {
+	N int
+}
+
+func (gl *gainLife) Cost() Cost { return nil }
+
+func (gl *gainLife) Commands(c *Context) []Command {
+	return []Command{&lifeCommand{Player: c.Player, N: gl.N}}
+}
+
+type lifeCommand struct {
+	Player *Player
+	N      int
+}
+
+func (lc *lifeCommand) Execute() { lc.Player.Life += lc.N }
+func (lc *lifeCommand) Undo()    { lc.Player.Life -= lc.N }
+
+// addMana is a mana ability that does not tap.
+type addMana struct {
+	Mana Mana
+}
+
+func (am *addMana) IsManaAbility() {}
+
+func (am *addMana) Cost() Cost { return nil }
+
+func (am *addMana) Commands(c *Context) []Command {
+	return []Command{&manaCommand{Player: c.Player, Mana: am.Mana}}
+}
+
+type manaCommand struct {
+	Player *Player
+	Mana   Mana
+}
+
+func (mc *manaCommand) Execute() { mc.Player.ManaPool = append(mc.Player.ManaPool, mc.Mana) }
+func (mc *manaCommand) Undo()    { mc.Player.ManaPool = mc.Player.ManaPool[:len(mc.Player.ManaPool)-1] }
+
+// scripted is an Agent that takes the actions returned by its function.
+type scripted struct {
+	Passive
+	f func(g *Game, p *Player) Action
+}
+
+func (s scripted) Priority(g *Game, p *Player) Action {
+	return s.f(g, p)
+}
+
+func script(f func(g *Game, p *Player) Action) Agent {
+	return scripted{f: f}
+}
+
+// queue returns an Agent taking actions in order in part, then passing.
+func queue(part Part, as ...Action) Agent {
+	return script(func(g *Game, p *Player) Action {
+		if g.CurrentPart != part || len(as) == 0 {
+			return Action{}
+		}
+		a := as[0]
+		as = as[1:]
+		return a
+	})
+}
+
+func TestPriority(t *testing.T) {
+	plains := obj(&Card{
+		Name:               "Plains",
+		Type:               Land,
+		ActivatedAbilities: []ActivatedAbility{&addMana{Mana: White}},
+	})
+	bear := obj(&Card{Name: "Bear", Type: Creature, Cost: MustParseManaCost("{W}"), Power: 2, Toughness: 2})
+	smallHeal := obj(&Card{Name: "Small Heal", Type: Instant, Spell: &gainLife{N: 1}})
+	bigHeal := obj(&Card{Name: "Big Heal", Type: Instant, Spell: &gainLife{N: 2}})
+	pray := obj(&Card{
+		Name:               "Prayer Altar",
+		Type:               Artifact,
+		ActivatedAbilities: []ActivatedAbility{&gainLife{N: 3}},
+	})
+
+	p0 := &Player{First: true, Life: 20, Hand: []*Object{plains, bear, smallHeal}}
+	p1 := &Player{Life: 20, Hand: []*Object{bigHeal}}
+	smallHeal.Owner, bigHeal.Owner = p0, p1
+	altar := &Permanent{Controller: p1, Type: Artifact, Card: pray}
+	p1.BattleField = []*Permanent{altar}
+	g := &Game{Players: []*Player{p0, p1}}
+
+	var land *Permanent
+	var stackSizes []int
+	p0.Agent = script(func(g *Game, p *Player) Action {
+		if g.CurrentPart != FirstMainPhase {
+			return Action{}
+		}
+		stackSizes = append(stackSizes, len(g.Stack))
+		switch {
+		case len(p.BattleField) == 0:
+			return Action{Card: plains}
+		case len(p.ManaPool) == 0 && len(p.Hand) == 2:
+			land = p.BattleField[0]
+			return Action{Ability: land.Card.ActivatedAbilities[0], Permanent: land}
+		case len(p.Hand) == 2:
+			if len(g.Stack) != 0 {
+				t.Errorf("mana ability used the stack")
+			}
+			return Action{Card: bear}
+		case len(g.Stack) == 2 && len(p.Hand) == 1:
+			// Respond to the opponent's response.
+			return Action{Card: smallHeal}
+		}
+		return Action{}
+	})
+	p1.Agent = queue(FirstMainPhase,
+		Action{Card: bigHeal},
+		Action{Ability: altar.Card.ActivatedAbilities[0], Permanent: altar},
+	)
+
+	g.Start()
+	for g.CurrentPart != FirstMainPhase {
+		if err := g.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(g.Stack) != 0 {
+		t.Fatalf("priority ended with %d objects on the stack", len(g.Stack))
+	}
+	// The bear is cast and p1 responds twice. Once the altar resolves, p0
+	// responds to Big Heal, then everything else resolves.
+	if p0.Life != 21 || p1.Life != 25 {
+		t.Errorf("life totals %d and %d, want 21 and 25", p0.Life, p1.Life)
+	}
+	if len(p0.BattleField) != 2 || p0.BattleField[1].Card != bear || !p0.BattleField[1].SummoningSick {
+		t.Errorf("bear did not resolve: %+v", p0.BattleField)
+	}
+	if !reflect.DeepEqual(p0.GraveYard, []*Object{smallHeal}) || !reflect.DeepEqual(p1.GraveYard, []*Object{bigHeal}) {
+		t.Errorf("graveyards %v and %v", p0.GraveYard, p1.GraveYard)
+	}
+	if len(p0.ManaPool) != 0 || len(p0.Hand) != 0 {
+		t.Errorf("mana pool %v and hand %v left", p0.ManaPool, p0.Hand)
+	}
+	// p0 acts three times with an empty stack and keeps priority after
+	// casting the bear. It gets priority back after p1's responses, and after
+	// each resolution.
+	want := []int{0, 0, 0, 1, 3, 2, 3, 2, 1, 0}
+	if !reflect.DeepEqual(stackSizes, want) {
+		t.Errorf("p0 got priority with stack sizes %v, want %v", stackSizes, want)
+	}
+}
+
+func TestIllegalActions(t *testing.T) {
+	swamp := obj(&Card{Name: "Swamp", Type: Land})
+	swamp2 := obj(&Card{Name: "Swamp", Type: Land})
+	bear := obj(&Card{Name: "Bear", Type: Creature, Cost: MustParseManaCost("{B}")})
+	freeBear := obj(&Card{Name: "Free Bear", Type: Creature})
+	p0 := &Player{Hand: []*Object{swamp, swamp2, bear, freeBear}}
+	p1 := &Player{}
+	g := &Game{Players: []*Player{p0, p1}, CurrentPart: FirstMainPhase}
+
+	if err := g.PlayLand(p0, swamp); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.PlayLand(p0, swamp2); err == nil {
+		t.Errorf("played a second land")
+	}
+	if err := g.Cast(p0, bear); err == nil {
+		t.Errorf("cast a spell without paying")
+	}
+	if err := g.Cast(p1, freeBear); err == nil {
+		t.Errorf("cast a creature from another player's hand")
+	}
+	g.Stack = []*StackObject{{Controller: p1, Card: bear}}
+	if err := g.Cast(p0, freeBear); err == nil {
+		t.Errorf("cast a creature with a nonempty stack")
+	}
+	g.Stack = nil
+	g.ActivePlayer = 1
+	if err := g.Cast(p0, freeBear); err == nil {
+		t.Errorf("cast a creature in the opponent's turn")
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import "fmt"
+
+// Target is a chosen target: a permanent or a player. The zero Target stands
+// for a target that became illegal.
+type Target struct {
+	Permanent *Permanent
+	Player    *Player
+}
+
+func (t Target) IsZero() bool {
+	return t.Permanent == nil && t.Player == nil
+}
+
+// TargetSpec is what a target of a spell or ability may be, e.g. "target
+// creature you don't control".
+type TargetSpec interface {
+	// Legal reports whether t may be targeted by the spell or ability of c,
+	// whose controller is c.Player.
+	Legal(c *Context, t Target) bool
+}
+
+// Targeted is implemented by the effects of spells and abilities that
+// target. The targets chosen for them are in Context.Targets, in the order of
+// Targets.
+type Targeted interface {
+	Targets() []TargetSpec
+}
+
+// targetSpecs returns the specs of e, nil if it does not target.
+func targetSpecs(e Effect) []TargetSpec {
+	if t, ok := e.(Targeted); ok {
+		return t.Targets()
+	}
+	return nil
+}
+
+// LegalTargets returns the players and permanents spec allows as targets for
+// the spell or ability of c.
+func (g *Game) LegalTargets(c *Context, spec TargetSpec) []Target {
+	var ts []Target
+	for _, p := range g.Players {
+		if t := (Target{Player: p}); spec.Legal(c, t) {
+			ts = append(ts, t)
+		}
+	}
+	for _, p := range g.Players {
+		for _, perm := range p.BattleField {
+			if t := (Target{Permanent: perm}); g.targetable(c, t) && spec.Legal(c, t) {
+				ts = append(ts, t)
+			}
+		}
+	}
+	return ts
+}
+
+// targetable reports whether t may be targeted by the spell or ability of c
+// at all: it is a player, or a permanent on the battlefield that does not
+// have hexproof unless c.Player controls it.
+func (g *Game) targetable(c *Context, t Target) bool {
+	if t.Permanent == nil {
+		return true
+	}
+	controller := g.Controller(t.Permanent)
+	if controller == nil {
+		return false
+	}
+	ch := g.Characteristics(t.Permanent)
+	return controller == c.Player || !ch.HasKeyword(Hexproof)
+}
+
+// checkTargets returns an error unless targets are as many as specs and each
+// is legal.
+func (g *Game) checkTargets(c *Context, specs []TargetSpec, targets []Target) error {
+	if len(targets) != len(specs) {
+		return fmt.Errorf("%d targets chosen for %d", len(targets), len(specs))
+	}
+	for i, t := range targets {
+		if !g.targetable(c, t) || !specs[i].Legal(c, t) {
+			return fmt.Errorf("target %d is illegal", i+1)
+		}
+	}
+	return nil
+}
+
+// chooseTargets has c.Player choose targets for specs among the legal ones,
+// and reports whether every spec has one. An illegal choice of the agent is
+// rejected for the first legal target.
+func (g *Game) chooseTargets(c *Context, specs []TargetSpec) ([]Target, bool) {
+	var targets []Target
+	for _, spec := range specs {
+		legal := g.LegalTargets(c, spec)
+		if len(legal) == 0 {
+			return nil, false
+		}
+		i := 0
+		if len(legal) > 1 {
+			i = c.Player.agent().ChooseTarget(g, c.Player, legal)
+		}
+		if i < 0 || i >= len(legal) {
+			g.reject(c.Player, fmt.Errorf("target %d of %d chosen", i, len(legal)))
+			i = 0
+		}
+		targets = append(targets, legal[i])
+	}
+	return targets, true
+}
+
+// targetCombinations returns every choice of legal targets for specs.
+func (g *Game) targetCombinations(c *Context, specs []TargetSpec) [][]Target {
+	if len(specs) == 0 {
+		return [][]Target{nil}
+	}
+	var tss [][]Target
+	for _, t := range g.LegalTargets(c, specs[0]) {
+		for _, rest := range g.targetCombinations(c, specs[1:]) {
+			tss = append(tss, append([]Target{t}, rest...))
+		}
+	}
+	return tss
+}
+
+// legalTargets returns targets with those that became illegal zeroed, and
+// whether any is still legal.
+func (g *Game) legalTargets(c *Context, specs []TargetSpec, targets []Target) ([]Target, bool) {
+	legal := make([]Target, len(targets))
+	ok := false
+	for i, t := range targets {
+		if g.targetable(c, t) && specs[i].Legal(c, t) {
+			legal[i] = t
+			ok = true
+		}
+	}
+	return legal, ok
+}
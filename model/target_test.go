@@ -0,0 +1,154 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// anyPlayer targets players.
+type anyPlayer struct{}
+
+func (ap *anyPlayer) Legal(c *Context, t Target) bool { return t.Player != nil }
+
+// creatureSpec targets creatures.
+type creatureSpec struct{}
+
+func (cs *creatureSpec) Legal(c *Context, t Target) bool {
+	return t.Permanent != nil && t.Permanent.Type == Creature
+}
+
+// drain makes each of its targets lose N life, and tracks the targets it saw.
+type drain struct {
+	Specs []TargetSpec
+	N     int
+	Seen  [][]Target
+}
+
+func (d *drain) Targets() []TargetSpec { return d.Specs }
+
+func (d *drain) Commands(c *Context) []Command {
+	d.Seen = append(d.Seen, c.Targets)
+	var cs []Command
+	for _, t := range c.Targets {
+		if t.Player != nil {
+			cs = append(cs, &lifeCommand{Player: t.Player, N: -d.N})
+		}
+	}
+	return cs
+}
+
+func TestTargets(t *testing.T) {
+	p0 := &Player{Life: 20}
+	p1 := &Player{Life: 20}
+	bear := &Permanent{Controller: p1, Type: Creature, Card: obj(&Card{Name: "Bear", Type: Creature, Toughness: 2})}
+	p1.BattleField = []*Permanent{bear}
+	g := &Game{Players: []*Player{p0, p1}}
+
+	spell := &drain{Specs: []TargetSpec{&anyPlayer{}, &creatureSpec{}}, N: 3}
+	shock := g.NewObject(&Card{Name: "Shock", Type: Instant, Spell: spell}, p0)
+	p0.Hand = []*Object{shock, shock}
+	c := &Context{Game: g, Player: p0}
+	if got := g.LegalTargets(c, &creatureSpec{}); !reflect.DeepEqual(got, []Target{{Permanent: bear}}) {
+		t.Errorf("legal targets %v", got)
+	}
+
+	if err := g.Cast(p0, shock, Target{Player: p1}); err == nil {
+		t.Errorf("cast with too few targets")
+	}
+	if err := g.Cast(p0, shock, Target{Permanent: bear}, Target{Player: p1}); err == nil {
+		t.Errorf("cast with illegal targets")
+	}
+	if err := g.Cast(p0, shock, Target{Player: p1}, Target{Permanent: bear}); err != nil {
+		t.Fatal(err)
+	}
+	// The bear leaves: the spell resolves for its remaining target.
+	p1.BattleField, bear.Controller = nil, nil
+	g.Resolve()
+	if p1.Life != 17 || !reflect.DeepEqual(spell.Seen, [][]Target{{{Player: p1}, {}}}) {
+		t.Errorf("life %d, targets seen %v", p1.Life, spell.Seen)
+	}
+
+	// With all its targets illegal, the spell does nothing.
+	single := &drain{Specs: []TargetSpec{&creatureSpec{}}}
+	p0.Hand = []*Object{g.NewObject(&Card{Name: "Fizzle", Type: Instant, Spell: single}, p0)}
+	p1.BattleField, bear.Controller = []*Permanent{bear}, p1
+	if err := g.Cast(p0, p0.Hand[0], Target{Permanent: bear}); err != nil {
+		t.Fatal(err)
+	}
+	p1.BattleField, bear.Controller = nil, nil
+	g.Resolve()
+	if len(single.Seen) != 0 || len(p0.GraveYard) != 2 {
+		t.Errorf("fizzled spell resolved: %v, graveyard %v", single.Seen, p0.GraveYard)
+	}
+}
+
+func TestHexproof(t *testing.T) {
+	p0 := &Player{Life: 20}
+	p1 := &Player{Life: 20}
+	mine := &Permanent{Controller: p0, Type: Creature, Card: obj(&Card{Name: "Mine", Type: Creature, Toughness: 1, Keywords: []Keyword{Hexproof}})}
+	theirs := &Permanent{Controller: p1, Type: Creature, Card: obj(&Card{Name: "Theirs", Type: Creature, Toughness: 1, Keywords: []Keyword{Hexproof}})}
+	p0.BattleField = []*Permanent{mine}
+	p1.BattleField = []*Permanent{theirs}
+	g := &Game{Players: []*Player{p0, p1}}
+
+	c := &Context{Game: g, Player: p0}
+	if got := g.LegalTargets(c, &creatureSpec{}); !reflect.DeepEqual(got, []Target{{Permanent: mine}}) {
+		t.Errorf("legal targets %v", got)
+	}
+	bolt := obj(&Card{Name: "Bolt", Type: Instant, Spell: &drain{Specs: []TargetSpec{&creatureSpec{}}}})
+	p0.Hand = []*Object{bolt}
+	if err := g.Cast(p0, bolt, Target{Permanent: theirs}); err == nil {
+		t.Errorf("targeted an opponent's creature with hexproof")
+	}
+	if err := g.Cast(p0, bolt, Target{Permanent: mine}); err != nil {
+		t.Errorf("could not target own creature with hexproof: %v", err)
+	}
+}
+
+func TestTriggerTargets(t *testing.T) {
+	p0 := &Player{Life: 20}
+	p1 := &Player{Life: 20}
+	g := &Game{Players: []*Player{p0, p1}}
+	ta := &targetedTrigger{drain: drain{Specs: []TargetSpec{&anyPlayer{}}, N: 1}}
+	perm := &Permanent{Controller: p0, Card: obj(&Card{Name: "Pinger", TriggeredAbilities: []TriggeredAbility{ta}})}
+	p0.BattleField = []*Permanent{perm}
+	p0.Agent = chooseLastOption{}
+
+	g.Emit(Event{Kind: CastSpell, Player: p0})
+	g.PutTriggersOnStack()
+	if len(g.Stack) != 1 || !reflect.DeepEqual(g.Stack[0].Targets, []Target{{Player: p1}}) {
+		t.Fatalf("stack %v", g.Stack)
+	}
+	g.Resolve()
+	if p1.Life != 19 {
+		t.Errorf("life %d, want 19", p1.Life)
+	}
+
+	// Without legal targets, the ability is removed.
+	ta.Specs = []TargetSpec{&creatureSpec{}}
+	g.Emit(Event{Kind: CastSpell, Player: p0})
+	g.PutTriggersOnStack()
+	if len(g.Stack) != 0 || len(g.Triggered) != 0 {
+		t.Errorf("%d on the stack and %d triggered", len(g.Stack), len(g.Triggered))
+	}
+}
+
+// targetedTrigger is a drain triggering on its controller's spells.
+type targetedTrigger struct {
+	drain
+}
+
+func (tt *targetedTrigger) Triggers(e *Event, c *Context) bool {
+	return e.Kind == CastSpell && e.Player == c.Player
+}
+
+// chooseLastOption is an agent choosing the last option and the last target.
+type chooseLastOption struct{ Passive }
+
+func (chooseLastOption) Choose(g *Game, p *Player, prompt string, options []any) int {
+	return len(options) - 1
+}
+
+func (chooseLastOption) ChooseTarget(g *Game, p *Player, legal []Target) int {
+	return len(legal) - 1
+}
@@ -0,0 +1,258 @@
+package model
+
+// MaxHandSize is the number of cards the active player keeps in the cleanup
+// step.
+const MaxHandSize = 7
+
+// Hook is called when the game enters a part of a turn, after the turn-based
+// actions of that part.
+type Hook func(g *Game)
+
+// AddHook registers h to be called whenever the game enters part.
+func (g *Game) AddHook(part Part, h Hook) {
+	g.Hooks[part] = append(g.Hooks[part], h)
+}
+
+// Execute performs c and emits the events it makes happen. Every change the
+// engine makes to the game goes through it, so that it can be recorded in the
+// journal.
+func (g *Game) Execute(c Command) {
+	c.Execute()
+	g.Invalidate()
+	if g.Journal != nil {
+		g.Journal.record(c)
+	}
+	if es, ok := c.(EventSource); ok {
+		for _, e := range es.Events() {
+			g.Emit(e)
+		}
+	}
+}
+
+// Active returns the active player.
+func (g *Game) Active() *Player {
+	return g.Players[g.ActivePlayer]
+}
+
+// Start begins the first turn of the game, that of ActivePlayer.
+func (g *Game) Start() {
+	g.enter(BeginningPhase, g.ActivePlayer)
+}
+
+// Advance moves the game to the next part of the turn, or to the turn of the
+// next player who does not skip their turns after the cleanup step. After a
+// combat damage step for first strike, the combat damage step comes again.
+// Mana pools empty between parts.
+func (g *Game) Advance() {
+	for _, p := range g.Players {
+		if len(p.ManaPool) > 0 {
+			g.Execute(&emptyManaPoolCommand{Player: p})
+		}
+	}
+	switch {
+	case g.CurrentPart == CleanupStep:
+		g.enter(BeginningPhase, g.nextTurn())
+	case g.CurrentPart == CombatDamageStep && g.Combat != nil && g.Combat.FirstStrike && g.Combat.DamageSteps == 1:
+		g.enter(CombatDamageStep, g.ActivePlayer)
+	default:
+		g.enter(g.CurrentPart+1, g.ActivePlayer)
+	}
+}
+
+// AdvanceTo advances until the game is in part, which may be in the next
+// turn.
+func (g *Game) AdvanceTo(part Part) {
+	g.Advance()
+	for g.CurrentPart != part {
+		g.Advance()
+	}
+}
+
+// nextTurn returns the index of the player whose turn comes next, the active
+// player again when all the others skip their turns.
+func (g *Game) nextTurn() int {
+	for i := 1; i < len(g.Players); i++ {
+		if j := (g.ActivePlayer + i) % len(g.Players); !g.Players[j].SkipsTurns {
+			return j
+		}
+	}
+	return g.ActivePlayer
+}
+
+func (g *Game) enter(part Part, active int) {
+	g.Execute(&enterCommand{Game: g, Part: part, ActivePlayer: active})
+	p := g.Active()
+	switch part {
+	case UntapStep:
+		g.Execute(&untapCommand{Player: p})
+	case UpkiipStep:
+		g.Emit(Event{Kind: BeginningOfUpkeep, Player: p})
+	case DrawStep:
+		// The player who goes first skips their first draw.
+		if !(p.First && p.Turn == 1) {
+			g.Draw(p)
+		}
+	case DeclateAtackersStep:
+		g.declareAttackers()
+	case DeclareBlockersStep:
+		g.declareBlockers()
+	case CombatDamageStep:
+		g.combatDamage()
+	case SecondMainPhase:
+		if g.Combat != nil {
+			g.Execute(&setCombatCommand{Game: g})
+		}
+	case CleanupStep:
+		if n := len(p.Hand) - MaxHandSize; n > 0 {
+			for _, c := range g.chooseCards(p, "discard", n) {
+				g.Execute(&MoveCommand{Game: g, Card: c, From: HandZone, To: GraveyardZone, Position: Bottom})
+			}
+		}
+		g.Execute(&clearDamageCommand{Game: g})
+		if len(g.UntilEndOfTurn) > 0 {
+			g.Execute(&endEffectsCommand{Game: g})
+		}
+	}
+	for _, h := range g.Hooks[part] {
+		h(g)
+	}
+}
+
+// enterCommand implements Command.
+var _ Command = (*enterCommand)(nil)
+
+// enterCommand moves the game to Part of ActivePlayer's turn, which starts a
+// new turn when Part is BeginningPhase.
+type enterCommand struct {
+	Game         *Game
+	Part         Part
+	ActivePlayer int
+
+	prevPart          Part
+	prevActive        int
+	prevLandsPlayed   int
+	prevAttacked      bool
+	prevTokensCreated int
+}
+
+func (ec *enterCommand) Execute() {
+	ec.prevPart, ec.prevActive = ec.Game.CurrentPart, ec.Game.ActivePlayer
+	ec.Game.CurrentPart, ec.Game.ActivePlayer = ec.Part, ec.ActivePlayer
+	if ec.Part == BeginningPhase {
+		p := ec.Game.Active()
+		p.Turn++
+		ec.prevLandsPlayed, p.LandsPlayed = p.LandsPlayed, 0
+		ec.prevAttacked, p.Attacked = p.Attacked, false
+		ec.prevTokensCreated, p.TokensCreated = p.TokensCreated, 0
+	}
+}
+
+func (ec *enterCommand) Undo() {
+	if ec.Part == BeginningPhase {
+		p := ec.Game.Active()
+		p.Turn--
+		p.LandsPlayed = ec.prevLandsPlayed
+		p.Attacked = ec.prevAttacked
+		p.TokensCreated = ec.prevTokensCreated
+	}
+	ec.Game.CurrentPart, ec.Game.ActivePlayer = ec.prevPart, ec.prevActive
+}
+
+// untapCommand implements Command.
+var _ Command = (*untapCommand)(nil)
+
+// untapCommand untaps the permanents of Player, which also stop being
+// summoning sick since Player has now controlled them since the start of
+// their turn, and may have a loyalty ability activated again.
+type untapCommand struct {
+	Player *Player
+
+	prev []Permanent
+}
+
+func (uc *untapCommand) Execute() {
+	uc.prev = uc.prev[:0]
+	for _, p := range uc.Player.BattleField {
+		uc.prev = append(uc.prev, *p)
+		p.Tapped = false
+		p.SummoningSick = false
+		p.LoyaltyActivated = false
+	}
+}
+
+func (uc *untapCommand) Undo() {
+	for i, p := range uc.Player.BattleField {
+		p.Tapped = uc.prev[i].Tapped
+		p.SummoningSick = uc.prev[i].SummoningSick
+		p.LoyaltyActivated = uc.prev[i].LoyaltyActivated
+	}
+}
+
+// Draw makes p draw a card, unless the draw is replaced.
+func (g *Game) Draw(p *Player) {
+	e := Event{Kind: Draws, Player: p}
+	g.Replace(&e)
+	if !e.Cancelled {
+		g.Execute(&drawCommand{Player: p})
+	}
+}
+
+// drawCommand implements Command.
+var _ Command = (*drawCommand)(nil)
+
+// drawCommand draws the top card of Player's library. Drawing from an empty
+// library is recorded on the player.
+type drawCommand struct {
+	Player *Player
+
+	drew      bool
+	prevEmpty bool
+}
+
+func (dc *drawCommand) Execute() {
+	p := dc.Player
+	if len(p.Library) == 0 {
+		dc.prevEmpty = p.DrewFromEmptyLibrary
+		p.DrewFromEmptyLibrary = true
+		return
+	}
+	dc.drew = true
+	p.Hand = append(p.Hand, p.Library[0])
+	p.Library = p.Library[1:]
+}
+
+func (dc *drawCommand) Events() []Event {
+	if !dc.drew {
+		return nil
+	}
+	return []Event{{Kind: Draws, Player: dc.Player, Card: dc.Player.Hand[len(dc.Player.Hand)-1]}}
+}
+
+func (dc *drawCommand) Undo() {
+	p := dc.Player
+	if !dc.drew {
+		p.DrewFromEmptyLibrary = dc.prevEmpty
+		return
+	}
+	c := p.Hand[len(p.Hand)-1]
+	p.Hand = p.Hand[:len(p.Hand)-1]
+	p.Library = append([]*Object{c}, p.Library...)
+}
+
+// emptyManaPoolCommand implements Command.
+var _ Command = (*emptyManaPoolCommand)(nil)
+
+type emptyManaPoolCommand struct {
+	Player *Player
+
+	prev []Mana
+}
+
+func (ec *emptyManaPoolCommand) Execute() {
+	ec.prev = ec.Player.ManaPool
+	ec.Player.ManaPool = nil
+}
+
+func (ec *emptyManaPoolCommand) Undo() {
+	ec.Player.ManaPool = ec.prev
+}
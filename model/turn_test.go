@@ -0,0 +1,185 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// obj returns an object of c outside of any game.
+func obj(c *Card) *Object {
+	return &Object{Card: c}
+}
+
+func cards(n int) []*Object {
+	var cs []*Object
+	for i := 0; i < n; i++ {
+		cs = append(cs, obj(&Card{}))
+	}
+	return cs
+}
+
+// own makes the players the owners of the cards in their hands and libraries.
+func own(ps ...*Player) {
+	for _, p := range ps {
+		for _, c := range append(p.Hand, p.Library...) {
+			c.Owner = p
+		}
+	}
+}
+
+func TestTurns(t *testing.T) {
+	first := &Player{First: true, Hand: cards(7), Library: cards(2)}
+	second := &Player{Hand: cards(7), Library: cards(1)}
+	own(first, second)
+	g := &Game{Players: []*Player{first, second}}
+
+	var parts []Part
+	for p := BeginningPhase; p <= CleanupStep; p++ {
+		g.AddHook(p, func(g *Game) {
+			parts = append(parts, g.CurrentPart)
+		})
+	}
+
+	g.Start()
+	g.AdvanceTo(FirstMainPhase)
+	if len(first.Hand) != 7 || first.Turn != 1 {
+		t.Fatalf("first player drew on the first turn: %d cards, turn %d", len(first.Hand), first.Turn)
+	}
+	sick := &Permanent{Controller: first, Card: obj(&Card{}), Tapped: true, SummoningSick: true}
+	first.BattleField = append(first.BattleField, sick)
+	first.ManaPool = []Mana{White}
+
+	g.Advance()
+	if first.ManaPool != nil {
+		t.Errorf("mana pool was not emptied: %v", first.ManaPool)
+	}
+
+	g.AdvanceTo(UntapStep)
+	if g.ActivePlayer != 1 || second.Turn != 1 {
+		t.Fatalf("active player %d on turn %d, want player 1 on turn 1", g.ActivePlayer, second.Turn)
+	}
+	if !sick.Tapped || !sick.SummoningSick {
+		t.Errorf("non-active player's permanent was untapped: %+v", sick)
+	}
+	g.AdvanceTo(CleanupStep)
+	if len(second.Hand) != 7 || len(second.GraveYard) != 1 {
+		t.Errorf("second player has %d cards in hand and %d in graveyard, want 7 and 1", len(second.Hand), len(second.GraveYard))
+	}
+
+	g.AdvanceTo(DrawStep)
+	if g.ActivePlayer != 0 || first.Turn != 2 {
+		t.Fatalf("active player %d on turn %d, want player 0 on turn 2", g.ActivePlayer, first.Turn)
+	}
+	if sick.Tapped || sick.SummoningSick {
+		t.Errorf("active player's permanent was not untapped: %+v", sick)
+	}
+	if len(first.Hand) != 8 || len(first.Library) != 1 {
+		t.Errorf("first player has %d cards in hand and %d in library, want 8 and 1", len(first.Hand), len(first.Library))
+	}
+
+	if second.DrewFromEmptyLibrary {
+		t.Errorf("second player drew from an empty library too early")
+	}
+	g.AdvanceTo(DrawStep)
+	if !second.DrewFromEmptyLibrary {
+		t.Errorf("second player did not draw from an empty library")
+	}
+
+	var want []Part
+	for i := 0; i < 4; i++ {
+		for p := BeginningPhase; p <= CleanupStep; p++ {
+			want = append(want, p)
+		}
+	}
+	want = want[:len(want)-int(CleanupStep-DrawStep)]
+	if !reflect.DeepEqual(parts, want) {
+		t.Errorf("hooks were called for %v, want %v", parts, want)
+	}
+}
+
+func TestSkipsTurns(t *testing.T) {
+	first := &Player{First: true, Library: cards(3)}
+	second := &Player{Library: cards(3), SkipsTurns: true}
+	own(first, second)
+	g := &Game{Players: []*Player{first, second}}
+
+	g.Start()
+	g.AdvanceTo(CleanupStep)
+	g.AdvanceTo(DrawStep)
+	if g.ActivePlayer != 0 || first.Turn != 2 || second.Turn != 0 {
+		t.Fatalf("active player %d, turns %d and %d, want player 0 and turns 2 and 0", g.ActivePlayer, first.Turn, second.Turn)
+	}
+	if len(first.Hand) != 1 || len(second.Hand) != 0 {
+		t.Errorf("players have %d and %d cards in hand, want 1 and 0", len(first.Hand), len(second.Hand))
+	}
+}
+
+func TestTurnCommandsUndo(t *testing.T) {
+	p := &Player{Hand: cards(9), Library: cards(1), ManaPool: []Mana{Black}}
+	own(p)
+	perm := &Permanent{Controller: p, Card: obj(&Card{}), Tapped: true, SummoningSick: true}
+	p.BattleField = []*Permanent{perm}
+	g := &Game{Players: []*Player{p}, CurrentPart: CleanupStep}
+	hand := append([]*Object(nil), p.Hand...)
+
+	cs := []Command{
+		&emptyManaPoolCommand{Player: p},
+		&enterCommand{Game: g, Part: BeginningPhase},
+		&untapCommand{Player: p},
+		&drawCommand{Player: p},
+		&drawCommand{Player: p},
+	}
+	for _, i := range []int{0, 4, 8} {
+		cs = append(cs, &MoveCommand{Game: g, Card: p.Hand[i], From: HandZone, To: GraveyardZone, Position: Bottom})
+	}
+	for _, c := range cs {
+		c.Execute()
+	}
+	if p.Turn != 1 || len(p.Hand) != 7 || len(p.GraveYard) != 3 || !p.DrewFromEmptyLibrary || perm.Tapped {
+		t.Fatalf("unexpected state after executing: %+v", p)
+	}
+	for i := len(cs) - 1; i >= 0; i-- {
+		cs[i].Undo()
+	}
+	if p.Turn != 0 || !reflect.DeepEqual(p.Hand, hand) || len(p.Library) != 1 || len(p.GraveYard) != 0 ||
+		p.DrewFromEmptyLibrary || !reflect.DeepEqual(p.ManaPool, []Mana{Black}) {
+		t.Errorf("got %+v after undoing", *p)
+	}
+	if !perm.Tapped || !perm.SummoningSick || g.CurrentPart != CleanupStep {
+		t.Errorf("permanent %+v in part %v after undoing", perm, g.CurrentPart)
+	}
+}
+
+// misbehaving is an agent whose choices are all illegal.
+type misbehaving struct{ Passive }
+
+func (misbehaving) ChooseCards(g *Game, p *Player, prompt string, n int) []*Object {
+	return []*Object{obj(&Card{Name: "Stranger"})}
+}
+
+func (misbehaving) ChooseTarget(g *Game, p *Player, legal []Target) int { return -1 }
+
+func (misbehaving) Choose(g *Game, p *Player, prompt string, options []any) int {
+	return len(options)
+}
+
+func TestRejectedChoices(t *testing.T) {
+	p := &Player{Agent: misbehaving{}, Hand: cards(MaxHandSize + 2)}
+	own(p)
+	g := &Game{Players: []*Player{p, {}}, CurrentPart: EndStep}
+	if i := g.Choose(p, "option", []any{1, 2}); i != 0 || g.rejection() == nil {
+		t.Errorf("chose option %d without rejection", i)
+	}
+	targets, _ := g.chooseTargets(&Context{Game: g, Player: p}, []TargetSpec{&anyPlayer{}})
+	if !reflect.DeepEqual(targets, []Target{{Player: p}}) || g.rejection() == nil {
+		t.Errorf("chose targets %v without rejection", targets)
+	}
+	// The discard is rejected for the last cards of the hand.
+	hand := p.Hand
+	if err := g.Step(); err == nil {
+		t.Errorf("discarded a card not in hand")
+	}
+	if !reflect.DeepEqual(p.GraveYard, hand[MaxHandSize:]) {
+		t.Errorf("graveyard %v, want the last cards of %v", p.GraveYard, hand)
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+var typeNames = map[Type]string{
+	Artifact:     "Artifact",
+	Creature:     "Creature",
+	Enchantment:  "Enchantment",
+	Instant:      "Instant",
+	Land:         "Land",
+	Planeswalker: "Planeswalker",
+	Socery:       "Sorcery",
+	Human:        "Human",
+	Worrier:      "Warrior",
+	Demon:        "Demon",
+	Cat:          "Cat",
+	Orc:          "Orc",
+	Dragon:       "Dragon",
+	Plains:       "Plains",
+	Island:       "Island",
+	Swamp:        "Swamp",
+	Mountain:     "Mountain",
+	Forest:       "Forest",
+}
+
+func (t Type) String() string {
+	return typeNames[t]
+}
+
+// IsCardType reports whether t is a card type rather than a subtype.
+func (t Type) IsCardType() bool {
+	return t <= Socery
+}
+
+// ParseType returns the type named s as printed on a type line, e.g.
+// "Creature" or "Warrior".
+func ParseType(s string) (Type, bool) {
+	for t, n := range typeNames {
+		if n == s {
+			return t, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package model
+
+// Zone is a place where objects can be during a game.
+type Zone int
+
+const (
+	LibraryZone Zone = iota
+	HandZone
+	BattlefieldZone
+	GraveyardZone
+	StackZone
+	ExileZone
+	CommandZone
+)
+
+var zoneNames = map[Zone]string{
+	LibraryZone:     "library",
+	HandZone:        "hand",
+	BattlefieldZone: "battlefield",
+	GraveyardZone:   "graveyard",
+	StackZone:       "stack",
+	ExileZone:       "exile",
+	CommandZone:     "command zone",
+}
+
+func (z Zone) String() string {
+	return zoneNames[z]
+}
+
+// Positions of a card moved into a zone. Top is the top of a library, whose
+// first card is drawn first; Bottom is the bottom of a library and the end of
+// the other zones. Other positions are indices from the top.
+const (
+	Top    = 0
+	Bottom = -1
+)
+
+// Cards returns the cards of p in z, nil for the battlefield and the stack,
+// which hold permanents and stack objects instead.
+func (p *Player) Cards(z Zone) *[]*Object {
+	switch z {
+	case LibraryZone:
+		return &p.Library
+	case HandZone:
+		return &p.Hand
+	case GraveyardZone:
+		return &p.GraveYard
+	case ExileZone:
+		return &p.Exile
+	case CommandZone:
+		return &p.Command
+	}
+	return nil
+}